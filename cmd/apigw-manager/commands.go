@@ -0,0 +1,472 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/manager"
+)
+
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+	// exitChanges is returned by plan with -detailed-exitcode when the gateway will be changed.
+	exitChanges = 3
+)
+
+// options are the flags of the commands.
+type options struct {
+	gateway   string
+	appCode   string
+	appSecret string
+	endpoint  string
+	urlTmpl   string
+	timeout   time.Duration
+
+	definition string
+	resources  string
+	data       dataFlag
+
+	delete           bool
+	swagger          bool
+	language         string
+	mcp              bool
+	version          string
+	comment          string
+	noPub            bool
+	publicKeyFile    string
+	outputFormat     string
+	detailedExitCode bool
+}
+
+// dataFlag collects the repeated key=value flags, which are the data variables of definition.yaml.
+type dataFlag map[string]interface{}
+
+func (d dataFlag) String() string {
+	keys := make([]string, 0, len(d))
+	for key := range d {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return strings.Join(keys, ",")
+}
+
+func (d dataFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("%q should be key=value", value)
+	}
+	d[key] = val
+
+	return nil
+}
+
+// commandContext is passed to each command.
+type commandContext struct {
+	ctx      context.Context
+	options  *options
+	manager  *manager.Manager
+	stdout   io.Writer
+	exitCode int
+}
+
+// command is a subcommand of apigw-manager.
+type command struct {
+	name  string
+	usage string
+	// definition indicates the command requires definition.yaml.
+	definition bool
+	flags      func(fs *flag.FlagSet, o *options)
+	run        func(c *commandContext) (interface{}, error)
+}
+
+var commands = []*command{
+	{
+		name:       "sync-apigw-config",
+		usage:      "sync the basic info of the gateway",
+		definition: true,
+		run: func(c *commandContext) (interface{}, error) {
+			result, err := c.manager.SyncBasicInfoContext(c.ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return result.Raw, nil
+		},
+	},
+	{
+		name:       "sync-related-apps",
+		usage:      "add the apps defined in related_apps as the related apps of the gateway",
+		definition: true,
+		run: func(c *commandContext) (interface{}, error) {
+			result, err := c.manager.SyncRelatedAppsContext(c.ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return result.Raw, nil
+		},
+	},
+	{
+		name:       "sync-apigw-stage",
+		usage:      "sync the stages, and the mcp servers of the stages with -mcp",
+		definition: true,
+		flags: func(fs *flag.FlagSet, o *options) {
+			fs.BoolVar(&o.mcp, "mcp", false, "sync the mcp servers of the stages")
+		},
+		run: func(c *commandContext) (interface{}, error) {
+			stages, err := c.manager.SyncStagesConfigContext(c.ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			result := map[string]interface{}{"stages": stageResults(stages)}
+			if c.options.mcp {
+				servers, err := c.manager.SyncStageMcpConfigContext(c.ctx)
+				if err != nil {
+					return result, err
+				}
+				result["mcp_servers"] = stageResults(servers)
+			}
+
+			return result, nil
+		},
+	},
+	{
+		name:       "sync-apigw-resources",
+		usage:      "sync the resources defined in resources.yaml",
+		definition: true,
+		flags: func(fs *flag.FlagSet, o *options) {
+			resourcesFlag(fs, o)
+			fs.BoolVar(&o.delete, "delete", false, "delete the resources which are not in resources.yaml")
+			fs.StringVar(&o.language, "language", "", "language of the resource docs in resources.yaml")
+		},
+		run: func(c *commandContext) (interface{}, error) {
+			content, err := os.ReadFile(c.options.resources)
+			if err != nil {
+				return nil, err
+			}
+
+			result, err := c.manager.SyncResourcesConfigContext(c.ctx, map[string]interface{}{
+				"content":  string(content),
+				"delete":   c.options.delete,
+				"language": c.options.language,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return result.Raw, nil
+		},
+	},
+	{
+		name:       "sync-resource-docs",
+		usage:      "sync the resource docs under resource_docs.basedir, or resource_docs.swagger with -swagger",
+		definition: true,
+		flags: func(fs *flag.FlagSet, o *options) {
+			fs.BoolVar(&o.swagger, "swagger", false, "sync the resource docs from resource_docs.swagger")
+		},
+		run: func(c *commandContext) (interface{}, error) {
+			sync := c.manager.SyncResourceDocByArchiveContext
+			if c.options.swagger {
+				sync = c.manager.SyncResourceDocBySwaggerContext
+			}
+
+			result, err := sync(c.ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return result.Raw, nil
+		},
+	},
+	{
+		name:       "grant-permissions",
+		usage:      "grant the permissions defined in grant_permissions",
+		definition: true,
+		run: func(c *commandContext) (interface{}, error) {
+			grants, err := c.manager.GrantPermissionsContext(c.ctx)
+			result := make([]map[string]interface{}, 0, len(grants))
+			for _, grant := range grants {
+				result = append(result, map[string]interface{}{
+					"bk_app_code":     grant.BkAppCode,
+					"grant_dimension": grant.GrantDimension,
+					"result":          grant.Raw,
+				})
+			}
+
+			return result, err
+		},
+	},
+	{
+		name:       "create-version-and-release",
+		usage:      "create a resource version and release it to the stages",
+		definition: true,
+		flags: func(fs *flag.FlagSet, o *options) {
+			fs.StringVar(&o.version, "version", "", "resource version, defaults to release.version")
+			fs.StringVar(&o.comment, "comment", "", "comment of the version, defaults to release.comment")
+			fs.BoolVar(&o.noPub, "no-pub", false, "create the version without releasing it")
+		},
+		run: createVersionAndRelease,
+	},
+	{
+		name:  "fetch-public-key",
+		usage: "fetch the public key of the gateway",
+		flags: func(fs *flag.FlagSet, o *options) {
+			fs.StringVar(&o.publicKeyFile, "o", "", "also write the public key to the file")
+		},
+		run: func(c *commandContext) (interface{}, error) {
+			publicKey, err := c.manager.GetPublicKeyStringContext(c.ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if c.options.publicKeyFile != "" {
+				err = os.WriteFile(c.options.publicKeyFile, []byte(publicKey), 0o644)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			return map[string]interface{}{"public_key": publicKey}, nil
+		},
+	},
+	{
+		name:       "plan",
+		usage:      "show what will be changed by syncing, without changing the gateway",
+		definition: true,
+		flags: func(fs *flag.FlagSet, o *options) {
+			resourcesFlag(fs, o)
+			fs.StringVar(&o.outputFormat, "output", "json", "output format, json or text")
+			fs.BoolVar(&o.detailedExitCode, "detailed-exitcode", false,
+				fmt.Sprintf("exit with %d when the gateway will be changed", exitChanges))
+		},
+		run: func(c *commandContext) (interface{}, error) {
+			content, err := os.ReadFile(c.options.resources)
+			if err != nil {
+				return nil, err
+			}
+
+			plan, err := c.manager.PlanContext(c.ctx, content)
+			if err != nil {
+				return nil, err
+			}
+
+			if c.options.detailedExitCode && plan.HasChanges() {
+				c.exitCode = exitChanges
+			}
+
+			return plan, nil
+		},
+	},
+}
+
+// stageResults keys the raw results by the stage names.
+func stageResults(stages []*manager.StageSyncResult) map[string]interface{} {
+	result := make(map[string]interface{}, len(stages))
+	for _, stage := range stages {
+		result[stage.Name] = stage.Raw
+	}
+
+	return result
+}
+
+func resourcesFlag(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.resources, "resources", "resources.yaml", "path of resources.yaml")
+	fs.StringVar(&o.resources, "r", "resources.yaml", "shorthand of -resources")
+}
+
+// createVersionAndRelease creates the version, a timestamp is appended if the version has been created.
+func createVersionAndRelease(c *commandContext) (interface{}, error) {
+	version, comment := c.options.version, c.options.comment
+	release, err := c.manager.GetDefinition().Get("release")
+	if err == nil {
+		if version == "" {
+			version = fmt.Sprintf("%v", release["version"])
+		}
+		if comment == "" && release["comment"] != nil {
+			comment = fmt.Sprintf("%v", release["comment"])
+		}
+	}
+	if version == "" || version == "<nil>" {
+		return nil, errors.New("version is required, set it by -version or release.version")
+	}
+
+	latest, err := c.manager.GetLatestResourceVersionContext(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the version is created already, or created with a build metadata by the former runs
+	if latest.Version == version || strings.HasPrefix(latest.Version, version+"+") {
+		version = fmt.Sprintf("%s+%s", version, time.Now().Format("20060102150405"))
+	}
+
+	result := map[string]interface{}{"version": version}
+	resourceVersion, err := c.manager.CreateResourceVersionContext(c.ctx, version, comment)
+	if err != nil {
+		return result, err
+	}
+	result["resource_version"] = resourceVersion.Raw
+	if c.options.noPub {
+		return result, nil
+	}
+
+	released, err := c.manager.ReleaseContext(c.ctx, version)
+	if err != nil {
+		return result, err
+	}
+	result["release"] = released.Raw
+	result["stage_names"] = released.StageNames
+
+	return result, nil
+}
+
+func findCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+
+	return nil
+}
+
+func usage(writer io.Writer) {
+	fmt.Fprintln(writer, "Usage: apigw-manager <command> [flags]")
+	fmt.Fprintln(writer, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(writer, "  %-28s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintln(writer, "\nRun 'apigw-manager <command> -h' for the flags of the command.")
+}
+
+// writeResult writes the result as json, the result which can be rendered as text is written directly if asked.
+func writeResult(writer io.Writer, format string, result interface{}) error {
+	if text, ok := result.(interface{ WriteText(io.Writer) error }); ok && format == "text" {
+		return text.WriteText(writer)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(result)
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+
+	cmd := findCommand(args[0])
+	if cmd == nil {
+		fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+		usage(stderr)
+		return exitUsage
+	}
+
+	o := &options{data: make(dataFlag)}
+	fs := flag.NewFlagSet("apigw-manager "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&o.gateway, "gateway", getenv("BK_APIGW_NAME"), "gateway name, env: BK_APIGW_NAME")
+	fs.StringVar(&o.gateway, "g", getenv("BK_APIGW_NAME"), "shorthand of -gateway")
+	fs.StringVar(&o.appCode, "app-code", "", "app code, env: BK_APP_CODE")
+	fs.StringVar(&o.appSecret, "app-secret", "", "app secret, env: BK_APP_SECRET")
+	fs.StringVar(&o.endpoint, "endpoint", "", "endpoint of bk-apigateway, defaults to {url-tmpl}/prod")
+	fs.StringVar(&o.urlTmpl, "url-tmpl", "", "template of the api url, env: BK_API_URL_TMPL")
+	fs.DurationVar(&o.timeout, "timeout", 0, "timeout of the command, such as 5m, no timeout by default")
+	if cmd.definition {
+		fs.StringVar(&o.definition, "file", "definition.yaml", "path of definition.yaml")
+		fs.StringVar(&o.definition, "f", "definition.yaml", "shorthand of -file")
+		fs.Var(o.data, "data", "data variable of definition.yaml as key=value, can be repeated")
+	}
+	if cmd.flags != nil {
+		cmd.flags(fs, o)
+	}
+
+	err := fs.Parse(args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	} else if err != nil {
+		return exitUsage
+	}
+	if o.gateway == "" {
+		fmt.Fprintln(stderr, "gateway name is required, set it by -gateway or BK_APIGW_NAME")
+		return exitUsage
+	}
+
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	c := &commandContext{ctx: ctx, options: o, stdout: stdout}
+	result, err := runCommand(cmd, c, getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s failed: %v\n", cmd.name, err)
+		_ = writeResult(stdout, "json", map[string]interface{}{
+			"command": cmd.name,
+			"gateway": o.gateway,
+			"error":   err.Error(),
+			"data":    result,
+		})
+		return exitFailure
+	}
+
+	err = writeResult(stdout, o.outputFormat, result)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to write the result: %v\n", err)
+		return exitFailure
+	}
+
+	return c.exitCode
+}
+
+func runCommand(cmd *command, c *commandContext, getenv func(string) string) (interface{}, error) {
+	o := c.options
+	config := bkapi.ClientConfig{
+		Endpoint:     o.endpoint,
+		BkApiUrlTmpl: o.urlTmpl,
+		AppCode:      o.appCode,
+		AppSecret:    o.appSecret,
+		Getenv:       getenv,
+	}
+
+	var err error
+	if cmd.definition {
+		c.manager, err = manager.NewManagerFromWithData(o.gateway, config, o.definition, map[string]interface{}(o.data))
+	} else {
+		c.manager, err = manager.NewDefaultManager(o.gateway, config)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd.run(c)
+}
@@ -0,0 +1,38 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// apigw-manager syncs the gateway configs defined in definition.yaml and resources.yaml to the api gateway.
+//
+// Usage:
+//
+//	apigw-manager <command> [flags]
+//
+// The gateway name and credentials can be set by the flags or the environment variables
+// BK_APIGW_NAME, BK_APP_CODE, BK_APP_SECRET and BK_API_URL_TMPL.
+// The requests are cancelled when the command is interrupted or exceeds the -timeout.
+// Each command writes a json document to stdout, and exits with 0 on success, 1 on failure and 2 on usage errors.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	// cancel the requests to the gateway when the job is interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv)
+	stop()
+
+	os.Exit(code)
+}
@@ -0,0 +1,198 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/internal/fakegateway"
+)
+
+var _ = Describe("Command", func() {
+	var (
+		gateway    *fakegateway.Gateway
+		definition string
+	)
+
+	runCli := func(getenv func(string) string, args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		if getenv == nil {
+			getenv = func(string) string { return "" }
+		}
+		code := run(context.Background(), args, &stdout, &stderr, getenv)
+
+		return code, stdout.String(), stderr.String()
+	}
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(GinkgoT().TempDir(), name)
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+
+		return path
+	}
+
+	decode := func(output string) map[string]interface{} {
+		var result map[string]interface{}
+		Expect(json.Unmarshal([]byte(output), &result)).To(Succeed())
+
+		return result
+	}
+
+	BeforeEach(func() {
+		gateway = fakegateway.New()
+		// the commands only care about the results of some requests
+		gateway.Fallback(map[string]interface{}{})
+
+		definition = writeFile("definition.yaml", `spec_version: 2
+release:
+  version: "{{ data.version }}"
+  comment: "by cli"
+apigateway:
+  description: "{{ settings.BK_APIGW_NAME }}"
+  maintainers: ["admin"]
+stages:
+  - name: prod
+grant_permissions:
+  - bk_app_code: demo
+    grant_dimension: api
+`)
+	})
+
+	AfterEach(func() {
+		gateway.Close()
+	})
+
+	It("should print the usage", func() {
+		code, _, stderr := runCli(nil)
+		Expect(code).To(Equal(exitUsage))
+		Expect(stderr).To(ContainSubstring("create-version-and-release"))
+
+		code, _, _ = runCli(nil, "unknown")
+		Expect(code).To(Equal(exitUsage))
+
+		code, _, stderr = runCli(nil, "sync-apigw-config")
+		Expect(code).To(Equal(exitUsage))
+		Expect(stderr).To(ContainSubstring("BK_APIGW_NAME"))
+	})
+
+	It("should sync the basic info by the environment variables", func() {
+		gateway.Reply("POST", "/api/v1/apis/demo/sync/", map[string]interface{}{"id": 1})
+		getenv := func(key string) string {
+			return map[string]string{"BK_APIGW_NAME": "demo", "BK_APP_CODE": "app"}[key]
+		}
+
+		code, stdout, stderr := runCli(getenv,
+			"sync-apigw-config", "-endpoint", gateway.URL, "-f", definition, "-data", "version=1.0.0")
+		Expect(code).To(Equal(exitOK), stderr)
+		Expect(decode(stdout)).To(HaveKeyWithValue("id", float64(1)))
+		Expect(gateway.Body("POST", "/api/v1/apis/demo/sync/")).To(HaveKeyWithValue("description", "demo"))
+	})
+
+	DescribeTable("should create the version and release it", func(latest string, expected types.GomegaMatcher) {
+		gateway.Reply("GET", "/api/v1/apis/demo/resource_versions/latest/", map[string]interface{}{"version": latest})
+
+		code, stdout, stderr := runCli(nil, "create-version-and-release",
+			"-g", "demo", "-endpoint", gateway.URL, "-f", definition, "-data", "version=1.0.0")
+		Expect(code).To(Equal(exitOK), stderr)
+		Expect(decode(stdout)).To(HaveKeyWithValue("version", expected))
+
+		body := gateway.Body("POST", "/api/v1/apis/demo/resource_versions/release/")
+		Expect(body).To(HaveKeyWithValue("version", expected))
+		Expect(body["stage_names"]).To(HaveLen(1))
+		Expect(gateway.Body("POST", "/api/v1/apis/demo/resource_versions/")).To(HaveKeyWithValue("comment", "by cli"))
+	},
+		Entry("older version", "0.9.0", Equal("1.0.0")),
+		Entry("version containing it", "11.0.0", Equal("1.0.0")),
+		Entry("version prefixed by it", "1.0.0-rc.1", Equal("1.0.0")),
+		Entry("same version", "1.0.0", MatchRegexp(`^1\.0\.0\+\d{14}$`)),
+		Entry("same version with build metadata", "1.0.0+20250101000000", MatchRegexp(`^1\.0\.0\+\d{14}$`)),
+	)
+
+	It("should fetch the public key", func() {
+		gateway.Reply("GET", "/api/v1/apis/demo/public_key/", map[string]interface{}{
+			"public_key": "-----BEGIN PUBLIC KEY-----",
+		})
+		path := filepath.Join(GinkgoT().TempDir(), "public.key")
+
+		code, stdout, stderr := runCli(nil, "fetch-public-key", "-g", "demo", "-endpoint", gateway.URL, "-o", path)
+		Expect(code).To(Equal(exitOK), stderr)
+		Expect(stdout).To(ContainSubstring("BEGIN PUBLIC KEY"))
+
+		content, err := os.ReadFile(path)
+		Expect(err).To(BeNil())
+		Expect(string(content)).To(Equal("-----BEGIN PUBLIC KEY-----"))
+	})
+
+	It("should report the failure", func() {
+		gateway.ReplyError("POST", "/api/v1/apis/demo/permissions/grant/", 40300, "forbidden")
+
+		code, stdout, stderr := runCli(nil, "grant-permissions", "-g", "demo", "-endpoint", gateway.URL, "-f", definition)
+		Expect(code).To(Equal(exitFailure))
+		Expect(stderr).To(ContainSubstring("forbidden"))
+		Expect(decode(stdout)).To(HaveKeyWithValue("command", "grant-permissions"))
+	})
+
+	It("should plan and exit with the detailed code", func() {
+		gateway.Reply("GET", "/api/v1/apis/demo/stages/", []interface{}{})
+		gateway.Reply("GET", "/api/v1/apis/demo/stages/with-resource-version/", []interface{}{})
+		resources := writeFile("resources.yaml", "swagger: \"2.0\"\npaths: {}\n")
+		args := []string{
+			"plan", "-g", "demo", "-endpoint", gateway.URL, "-f", definition, "-r", resources,
+			"-data", "version=1.0.0",
+		}
+
+		code, stdout, stderr := runCli(nil, args...)
+		Expect(code).To(Equal(exitOK), stderr)
+		Expect(stdout).To(ContainSubstring(`"action": "add"`))
+
+		code, stdout, _ = runCli(nil, append(args, "-output", "text", "-detailed-exitcode")...)
+		Expect(code).To(Equal(exitChanges))
+		Expect(stdout).To(ContainSubstring("+ stage prod"))
+	})
+
+	It("should cancel the requests by the timeout", func() {
+		done := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-done:
+			}
+		}))
+		defer server.Close()
+		defer close(done)
+
+		start := time.Now()
+		code, _, stderr := runCli(nil, "fetch-public-key", "-g", "demo", "-endpoint", server.URL, "-timeout", "100ms")
+		Expect(code).To(Equal(exitFailure))
+		Expect(stderr).To(ContainSubstring("deadline exceeded"))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	})
+
+	It("should sync the related apps", func() {
+		path := writeFile("definition.yaml", "spec_version: 2\nrelated_apps:\n  - app1\n")
+
+		code, _, stderr := runCli(nil, "sync-related-apps", "-g", "demo", "-endpoint", gateway.URL, "-f", path)
+		Expect(code).To(Equal(exitOK), stderr)
+		Expect(gateway.Body("POST", "/api/v1/apis/demo/related-apps/")["target_app_codes"]).To(HaveLen(1))
+	})
+})
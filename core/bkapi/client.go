@@ -15,8 +15,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/TencentBlueKing/gopkg/logging"
 	gentleman "gopkg.in/h2non/gentleman.v2"
@@ -25,22 +29,121 @@ import (
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
 )
 
+type globalOptionEntry struct {
+	id  uint64
+	opt define.BkApiClientOption
+}
+
 // the common options for all bkapi clients
-var globalBkapiClientOptions []define.BkApiClientOption
+var globalBkapiClientOptions struct {
+	lock    sync.RWMutex
+	nextId  uint64
+	entries []globalOptionEntry
+}
+
+// RegisterGlobalBkapiClientOption use to register a global bkapi client option for the clients created afterwards,
+// it is safe to call it concurrently. The returned function unregisters the option, and it is idempotent.
+func RegisterGlobalBkapiClientOption(opt define.BkApiClientOption) (unregister func()) {
+	globalBkapiClientOptions.lock.Lock()
+	defer globalBkapiClientOptions.lock.Unlock()
+
+	globalBkapiClientOptions.nextId++
+	id := globalBkapiClientOptions.nextId
+	globalBkapiClientOptions.entries = append(globalBkapiClientOptions.entries, globalOptionEntry{id: id, opt: opt})
+
+	return func() {
+		globalBkapiClientOptions.lock.Lock()
+		defer globalBkapiClientOptions.lock.Unlock()
+
+		for i, entry := range globalBkapiClientOptions.entries {
+			if entry.id != id {
+				continue
+			}
 
-// RegisterGlobalBkapiClientOption use to register a global bkapi client option.
-// Warning: this function is not safe for concurrent access.
-func RegisterGlobalBkapiClientOption(opt define.BkApiClientOption) {
-	globalBkapiClientOptions = append(globalBkapiClientOptions, opt)
+			entries := globalBkapiClientOptions.entries
+			globalBkapiClientOptions.entries = append(entries[:i:i], entries[i+1:]...)
+
+			return
+		}
+	}
 }
 
-// NewBkApiClient creates a new BkApiClient.
-func NewBkApiClient(
-	apiName string,
-	configProvider define.ClientConfigProvider,
-	options ...define.BkApiClientOption,
-) (define.BkApiClient, error) {
-	config := configProvider.ProvideConfig(apiName)
+// RegisterGlobalMiddleware use to register the middlewares for all bkapi clients created afterwards,
+// it is safe to call it concurrently. The returned function unregisters the middlewares.
+func RegisterGlobalMiddleware(mws ...define.Middleware) (unregister func()) {
+	return RegisterGlobalBkapiClientOption(OptMiddleware(mws...))
+}
+
+// snapshotGlobalBkapiClientOptions returns the global options at the moment,
+// so the registering during the creation of a client does not affect it.
+func snapshotGlobalBkapiClientOptions() []define.BkApiClientOption {
+	globalBkapiClientOptions.lock.RLock()
+	defer globalBkapiClientOptions.lock.RUnlock()
+
+	opts := make([]define.BkApiClientOption, 0, len(globalBkapiClientOptions.entries))
+	for _, entry := range globalBkapiClientOptions.entries {
+		opts = append(opts, entry.opt)
+	}
+
+	return opts
+}
+
+// newConnectionPool returns the connection pool for the client, the clients sharing the transport
+// are grouped by the scheme and host of the endpoint. Otherwise, the client borrows http.DefaultTransport
+// like the other http clients, and owns a copy of it once a transport option is applied.
+// Nil is returned when the default transport is replaced (e.g. by a mocking library), so it is used directly.
+func newConnectionPool(config define.ClientConfig) (*internal.ConnectionPool, error) {
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, nil
+	}
+
+	transportConfig, ok := config.(define.TransportConfig)
+	if !ok || !transportConfig.IsTransportShared() {
+		return internal.BorrowConnectionPool(defaultTransport), nil
+	}
+
+	endpoint, err := url.Parse(config.GetUrl())
+	if err != nil {
+		return nil, define.ErrorWrapf(define.ErrConfigInvalid, "endpoint %s is invalid: %v", config.GetUrl(), err)
+	}
+
+	return internal.SharedTransportRegistry.Acquire(
+		fmt.Sprintf("%s://%s", endpoint.Scheme, endpoint.Host), defaultTransport.Clone,
+	), nil
+}
+
+func newBackendClient(apiName string, config define.ClientConfig) (define.BkApiClient, error) {
+	var backend define.Backend
+	if backendConfig, ok := config.(define.BackendConfig); ok {
+		backend = backendConfig.GetBackend()
+	}
+
+	switch backend {
+	case "", define.BackendGentleman, define.BackendNative:
+	default:
+		return nil, define.ErrorWrapf(define.ErrConfigInvalid, "unknown backend %s", backend)
+	}
+
+	pool, err := newConnectionPool(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if backend == define.BackendNative {
+		client, err := internal.NewHttpBkApiClient(apiName, &http.Client{Transport: http.DefaultTransport}, config)
+		if err != nil {
+			releaseConnectionPool(pool)
+			return nil, err
+		}
+
+		if pool != nil {
+			client.UseConnectionPool(pool)
+		}
+
+		return client, nil
+	}
+
 	client, err := internal.NewBkApiClient(
 		apiName,
 		gentleman.New(),
@@ -49,22 +152,68 @@ func NewBkApiClient(
 		},
 		config,
 	)
+	if err != nil {
+		releaseConnectionPool(pool)
+		return nil, err
+	}
+
+	if pool != nil {
+		client.UseConnectionPool(pool)
+	}
+
+	return client, nil
+}
+
+func releaseConnectionPool(pool *internal.ConnectionPool) {
+	if pool != nil {
+		pool.Release()
+	}
+}
+
+func signatureOptions(config define.ClientConfig) []define.BkApiClientOption {
+	signatureConfig, ok := config.(define.SignatureConfig)
+	if !ok || signatureConfig.GetSignatureKey() == "" {
+		return nil
+	}
+
+	return []define.BkApiClientOption{
+		internal.NewTransportMiddlewareOption(internal.NewSignatureMiddleware(signatureConfig.GetSignatureKey())),
+	}
+}
+
+// NewBkApiClient creates a new BkApiClient.
+func NewBkApiClient(
+	apiName string,
+	configProvider define.ClientConfigProvider,
+	options ...define.BkApiClientOption,
+) (define.BkApiClient, error) {
+	config := configProvider.ProvideConfig(apiName)
+	client, err := newBackendClient(apiName, config)
 	if err != nil {
 		return nil, err
 	}
 
-	for phase, opts := range map[string][]define.BkApiClientOption{
-		"global": globalBkapiClientOptions,
-		"config": config.GetClientOptions(),
-		"client": options,
+	// the options are applied in order, so the global middlewares wrap the config and client ones.
+	// The signature and the debug dumper wrap the transport, inside the operation middlewares as well,
+	// so the requests are signed after all the middlewares change them, and the debug dumps the final requests
+	for _, phase := range []struct {
+		name string
+		opts []define.BkApiClientOption
+	}{
+		{name: "global", opts: snapshotGlobalBkapiClientOptions()},
+		{name: "config", opts: config.GetClientOptions()},
+		{name: "client", opts: options},
+		{name: "signature", opts: signatureOptions(config)},
+		{name: "debug", opts: debugOptions(config)},
 	} {
-		if len(opts) == 0 {
+		if len(phase.opts) == 0 {
 			continue
 		}
 
-		err := client.Apply(opts...)
+		err := client.Apply(phase.opts...)
 		if err != nil {
-			return nil, define.ErrorWrapf(err, "failed to apply options to client %s, phase %s", apiName, phase)
+			_ = client.Close()
+			return nil, define.ErrorWrapf(err, "failed to apply options to client %s, phase %s", apiName, phase.name)
 		}
 	}
 
@@ -92,6 +241,12 @@ type ClientConfig struct {
 
 	AppTenantID string
 
+	// SignRequest signs each request by the HMAC of the app secret, instead of sending the app secret,
+	// see SignatureVerifier for the signature details. The bk_app_secret is no longer sent in the
+	// X-Bkapi-Authorization header, so it only works with a gateway or a server which verifies the signatures.
+	// The requests are signed after all the middlewares, so the middlewares can still change them.
+	SignRequest bool
+
 	// AccessToken is the access token of the user and app, optional.
 	AccessToken string
 	// AuthorizationParams is the authorization params of the user and app, optional.
@@ -109,6 +264,32 @@ type ClientConfig struct {
 
 	// ClientConfig will apply to the client.
 	ClientOptions []define.BkApiClientOption
+
+	// Backend is the implementation to send the requests, defaults to define.BackendGentleman.
+	// define.BackendNative sends the requests by net/http directly, which has less overhead for each request.
+	Backend define.Backend
+
+	// TLSCertFile, TLSKeyFile and TLSCAFile are the PEM files to enable the mutual TLS, see OptMTLSFromFiles.
+	// Default: os.Getenv("BK_API_TLS_CERT_FILE"), os.Getenv("BK_API_TLS_KEY_FILE") and os.Getenv("BK_API_TLS_CA_FILE")
+	TLSCertFile string
+	TLSKeyFile  string
+	TLSCAFile   string
+
+	// Debug enables the wire dump when the client name matches the comma separated globs,
+	// "1", "true" and "*" match all the clients. It can be overridden at runtime by SetDebug.
+	// Default: os.Getenv("BKAPI_DEBUG")
+	Debug string
+	// DebugFile is the file to append the dumps, defaults to the client logger.
+	// Default: os.Getenv("BKAPI_DEBUG_FILE")
+	DebugFile string
+	// DebugBodyLimit is the maximum bytes of the request and response body to dump, defaults to 4096.
+	// Default: os.Getenv("BKAPI_DEBUG_BODY_LIMIT")
+	DebugBodyLimit int
+
+	// ShareTransport makes the clients of the same endpoint (scheme and host) share a transport,
+	// so that they share the connection pool. The shared transport is configured by the first client,
+	// keep the transport options of these clients the same.
+	ShareTransport bool
 }
 
 func (c *ClientConfig) setAuthAccessTokenAuthParams(params map[string]string) bool {
@@ -132,7 +313,8 @@ func (c *ClientConfig) setAppAuthParams(params map[string]string) {
 		params["bk_app_code"] = c.AppCode
 	}
 
-	if c.AppSecret != "" {
+	// the app secret is proved by the signature
+	if c.AppSecret != "" && !c.SignRequest {
 		params["bk_app_secret"] = c.AppSecret
 	}
 }
@@ -222,6 +404,34 @@ func (c *ClientConfig) initBkApiConfig() {
 	})
 }
 
+func (c *ClientConfig) initTLSConfig() {
+	if c.TLSCertFile == "" {
+		c.TLSCertFile = c.getEnv("BK_API_TLS_CERT_FILE")
+	}
+
+	if c.TLSKeyFile == "" {
+		c.TLSKeyFile = c.getEnv("BK_API_TLS_KEY_FILE")
+	}
+
+	if c.TLSCAFile == "" {
+		c.TLSCAFile = c.getEnv("BK_API_TLS_CA_FILE")
+	}
+}
+
+func (c *ClientConfig) initDebugConfig() {
+	if c.Debug == "" {
+		c.Debug = c.getEnv("BKAPI_DEBUG")
+	}
+
+	if c.DebugFile == "" {
+		c.DebugFile = c.getEnv("BKAPI_DEBUG_FILE")
+	}
+
+	if c.DebugBodyLimit == 0 {
+		c.DebugBodyLimit, _ = strconv.Atoi(c.getEnv("BKAPI_DEBUG_BODY_LIMIT"))
+	}
+}
+
 func (c *ClientConfig) initLogger() {
 	if c.Logger != nil {
 		return
@@ -243,6 +453,8 @@ func (c *ClientConfig) initConfig(apiName string) {
 
 	c.initAppConfig()
 	c.initBkApiConfig()
+	c.initTLSConfig()
+	c.initDebugConfig()
 	c.initLogger()
 }
 
@@ -308,7 +520,49 @@ func (c *ClientConfig) GetLogger() logging.Logger {
 	return c.Logger
 }
 
-// GetClientOptions method will return the client options.
+// GetClientOptions method will return the client options,
+// the mutual TLS option is the first one when the TLS files are set, so it can be overridden.
 func (c *ClientConfig) GetClientOptions() []define.BkApiClientOption {
-	return c.ClientOptions
+	if c.TLSCertFile == "" && c.TLSKeyFile == "" && c.TLSCAFile == "" {
+		return c.ClientOptions
+	}
+
+	options := make([]define.BkApiClientOption, 0, len(c.ClientOptions)+1)
+	options = append(options, OptMTLSFromFiles(c.TLSCertFile, c.TLSKeyFile, c.TLSCAFile))
+
+	return append(options, c.ClientOptions...)
+}
+
+// GetBackend method will return the backend of the client.
+func (c *ClientConfig) GetBackend() define.Backend {
+	return c.Backend
+}
+
+// IsTransportShared method will return whether the client shares the transport.
+func (c *ClientConfig) IsTransportShared() bool {
+	return c.ShareTransport
+}
+
+// GetSignatureKey method will return the app secret to sign the requests when SignRequest is enabled.
+func (c *ClientConfig) GetSignatureKey() string {
+	if !c.SignRequest {
+		return ""
+	}
+
+	return c.AppSecret
+}
+
+// GetDebug method will return the client name patterns to dump the wire traffic.
+func (c *ClientConfig) GetDebug() string {
+	return c.Debug
+}
+
+// GetDebugFile method will return the file to write the dumps.
+func (c *ClientConfig) GetDebugFile() string {
+	return c.DebugFile
+}
+
+// GetDebugBodyLimit method will return the maximum bytes of the body to dump.
+func (c *ClientConfig) GetDebugBodyLimit() int {
+	return c.DebugBodyLimit
 }
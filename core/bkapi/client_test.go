@@ -12,7 +12,11 @@
 package bkapi_test
 
 import (
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -110,6 +114,204 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Context("Backend", func() {
+		It("should fail when the backend is unknown", func() {
+			_, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+				Endpoint: "http://api.example.com/",
+				Backend:  "unknown",
+			})
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should send the request by native backend with options", func() {
+			var request *http.Request
+			roundTripper := mock.NewMockRoundTripper(ctrl)
+			roundTripper.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				request = req
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			})
+
+			client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+				Endpoint: "http://api.example.com/",
+				Backend:  define.BackendNative,
+				AppCode:  "app",
+			}, bkapi.OptTransport(roundTripper), bkapi.OptSetRequestHeader("X-Testing", "testing"))
+			Expect(err).To(BeNil())
+
+			_, err = client.NewOperation(
+				bkapi.OperationConfig{Method: "GET", Path: "/hello/{name}"},
+				bkapi.OptSetRequestQueryParam("foo", "bar"),
+				bkapi.OptSetRequestPathParams(map[string]string{"name": "world"}),
+			).Request()
+			Expect(err).To(BeNil())
+
+			Expect(request.URL.String()).To(Equal("http://api.example.com/hello/world?foo=bar"))
+			Expect(request.Header.Get("X-Testing")).To(Equal("testing"))
+			Expect(request.Header.Get("X-Bkapi-Authorization")).To(ContainSubstring(`"bk_app_code":"app"`))
+		})
+	})
+
+	Context("Middleware", func() {
+		recorder := func(calls *[]string, name string) define.Middleware {
+			return func(next define.RoundTrip) define.RoundTrip {
+				return func(request *http.Request) (*http.Response, error) {
+					metadata, _ := define.OperationMetadataFromContext(request.Context())
+					*calls = append(*calls, fmt.Sprintf("%s:%s:%s", name, metadata.FullName, metadata.Path))
+					return next(request)
+				}
+			}
+		}
+
+		DescribeTable("should wrap the requests in order", func(backend define.Backend) {
+			var calls []string
+			roundTripper := mock.NewMockRoundTripper(ctrl)
+			roundTripper.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, "transport")
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+			})
+
+			client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+				Endpoint:      "http://api.example.com/",
+				Backend:       backend,
+				ClientOptions: []define.BkApiClientOption{bkapi.OptMiddleware(recorder(&calls, "config"))},
+			}, bkapi.OptTransport(roundTripper), bkapi.OptMiddleware(recorder(&calls, "client")))
+			Expect(err).To(BeNil())
+
+			_, err = client.NewOperation(
+				bkapi.OperationConfig{Name: "hello", Method: "GET", Path: "/hello/{name}"},
+				bkapi.OptSetRequestPathParams(map[string]string{"name": "world"}),
+				bkapi.OptMiddleware(recorder(&calls, "operation")),
+			).Request()
+			Expect(err).To(BeNil())
+
+			Expect(calls).To(Equal([]string{
+				"config:testing.api.hello:/hello/{name}",
+				"client:testing.api.hello:/hello/{name}",
+				"operation:testing.api.hello:/hello/{name}",
+				"transport",
+			}))
+		},
+			Entry("gentleman", define.BackendGentleman),
+			Entry("native", define.BackendNative),
+		)
+	})
+
+	Context("GlobalOption", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		counter := func(count *int64) define.Middleware {
+			return func(next define.RoundTrip) define.RoundTrip {
+				return func(request *http.Request) (*http.Response, error) {
+					atomic.AddInt64(count, 1)
+					return next(request)
+				}
+			}
+		}
+
+		request := func() {
+			client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+				Endpoint: server.URL,
+				Backend:  define.BackendNative,
+			})
+			Expect(err).To(BeNil())
+			defer client.Close()
+
+			_, err = client.NewOperation(bkapi.OperationConfig{Method: "GET", Path: "/"}).Request()
+			Expect(err).To(BeNil())
+		}
+
+		It("should apply the global middlewares until unregistered", func() {
+			var count int64
+			unregister := bkapi.RegisterGlobalMiddleware(counter(&count))
+
+			request()
+			Expect(atomic.LoadInt64(&count)).To(Equal(int64(1)))
+
+			unregister()
+			unregister()
+
+			request()
+			Expect(atomic.LoadInt64(&count)).To(Equal(int64(1)))
+		})
+
+		It("should snapshot the global options when creating a client", func() {
+			var count int64
+			unregisterNested := func() {}
+			option := mock.NewMockBkApiClientOption(ctrl)
+			option.EXPECT().ApplyToClient(gomock.Any()).DoAndReturn(func(define.BkApiClient) error {
+				// registering during the creation does not affect the client being created
+				unregisterNested = bkapi.RegisterGlobalMiddleware(counter(&count))
+				return nil
+			})
+			unregister := bkapi.RegisterGlobalBkapiClientOption(option)
+			defer func() { unregisterNested() }()
+
+			request()
+			unregister()
+			Expect(atomic.LoadInt64(&count)).To(Equal(int64(0)))
+
+			request()
+			Expect(atomic.LoadInt64(&count)).To(Equal(int64(1)))
+		})
+
+		It("should be safe to register and create clients concurrently", func() {
+			var (
+				count int64
+				wg    sync.WaitGroup
+			)
+
+			for i := 0; i < 8; i++ {
+				wg.Add(3)
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					bkapi.RegisterGlobalMiddleware(counter(&count))()
+				}()
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					define.EnableStackTraceErrorWrapf()
+					defer define.ResetErrorWrapf()
+
+					Expect(define.ErrorWrapf(define.ErrConfigInvalid, "wrapped")).To(MatchError(define.ErrConfigInvalid))
+				}()
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					request()
+				}()
+			}
+
+			wg.Wait()
+		})
+
+		It("should keep ErrorWrapf assignable", func() {
+			wrapf := define.ErrorWrapf
+			defer func() { define.ErrorWrapf = wrapf }()
+
+			define.ErrorWrapf = func(err error, format string, args ...interface{}) error {
+				return fmt.Errorf("custom: %w", err)
+			}
+			Expect(define.ErrorWrapf(define.ErrConfigInvalid, "wrapped")).To(MatchError("custom: config invalid"))
+		})
+	})
+
 	Context("ClientConfig", func() {
 		It("should clone a new config", func() {
 			config := bkapi.ClientConfig{}
@@ -14,6 +14,7 @@ package bkapi
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
 )
@@ -22,26 +23,34 @@ import (
 var ErrClientConfigRegistryValidationFailed = fmt.Errorf("client config validation failed")
 
 // ClientConfigRegistry manage multiple client configs.
+// It is safe to register and provide the configs concurrently.
 type ClientConfigRegistry struct {
-	defaultConfigProvider define.ClientConfigProvider
+	defaultConfigProvider atomic.Value
 	configs               sync.Map
 }
 
+type defaultConfigProviderHolder struct {
+	provider define.ClientConfigProvider
+}
+
 // ProvideConfig return a client config
 func (r *ClientConfigRegistry) ProvideConfig(apiName string) define.ClientConfig {
-	provider := r.defaultConfigProvider
+	if value, ok := r.configs.Load(apiName); ok {
+		return value.(define.ClientConfigProvider).ProvideConfig(apiName)
+	}
 
-	value, ok := r.configs.Load(apiName)
-	if ok {
-		provider = value.(define.ClientConfigProvider)
+	// the default provider is not registered in a zero value registry
+	holder, ok := r.defaultConfigProvider.Load().(defaultConfigProviderHolder)
+	if !ok || holder.provider == nil {
+		return ClientConfig{}.ProvideConfig(apiName)
 	}
 
-	return provider.ProvideConfig(apiName)
+	return holder.provider.ProvideConfig(apiName)
 }
 
 // RegisterDefaultConfig register default client config
 func (r *ClientConfigRegistry) RegisterDefaultConfig(provider define.ClientConfigProvider) error {
-	r.defaultConfigProvider = provider
+	r.defaultConfigProvider.Store(defaultConfigProviderHolder{provider: provider})
 	return nil
 }
 
@@ -55,6 +64,11 @@ func (r *ClientConfigRegistry) RegisterClientConfig(apiName string, provider def
 	return nil
 }
 
+// UnregisterClientConfig removes the client config of the api, the default config will be used instead.
+func (r *ClientConfigRegistry) UnregisterClientConfig(apiName string) {
+	r.configs.Delete(apiName)
+}
+
 // NewClientConfigRegistry create a client config registry
 func NewClientConfigRegistry() *ClientConfigRegistry {
 	var registry ClientConfigRegistry
@@ -12,6 +12,9 @@
 package bkapi_test
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -70,5 +73,74 @@ var _ = Describe("Config", func() {
 
 			Expect(config).To(Equal(providedConfig))
 		})
+
+		It("should return default config after unregistering", func() {
+			apiName := "should-not-exist"
+			Expect(registry.RegisterClientConfig(apiName, ClientConfig{
+				Endpoint: "http://special.example.com/",
+			})).To(Succeed())
+
+			registry.UnregisterClientConfig(apiName)
+			config := registry.ProvideConfig(apiName)
+
+			Expect(config.GetUrl()).To(Equal("http://should-not-exist.example.com/prod/"))
+		})
+
+		It("should be safe to register and provide configs concurrently", func() {
+			var wg sync.WaitGroup
+
+			for i := 0; i < 8; i++ {
+				apiName := fmt.Sprintf("api-%d", i)
+				wg.Add(3)
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					Expect(registry.RegisterDefaultConfig(defaultConfig)).To(Succeed())
+				}()
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					Expect(registry.RegisterClientConfig(apiName, defaultConfig)).To(Succeed())
+					registry.UnregisterClientConfig(apiName)
+				}()
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					Expect(registry.ProvideConfig(apiName).GetName()).To(Equal(apiName))
+				}()
+			}
+
+			wg.Wait()
+		})
+
+		It("should work with a zero value registry", func() {
+			var zero ClientConfigRegistry
+			var wg sync.WaitGroup
+
+			Expect(zero.ProvideConfig("default").GetName()).To(Equal("default"))
+
+			for i := 0; i < 8; i++ {
+				apiName := fmt.Sprintf("api-%d", i)
+				wg.Add(1)
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					Expect(zero.RegisterClientConfig(apiName, ClientConfig{
+						Endpoint: "http://special.example.com/",
+					})).To(Succeed())
+					Expect(zero.ProvideConfig(apiName).GetUrl()).To(Equal("http://special.example.com/"))
+				}()
+			}
+
+			wg.Wait()
+		})
 	})
 })
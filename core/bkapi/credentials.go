@@ -0,0 +1,116 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+const authorizationHeader = "X-Bkapi-Authorization"
+
+type userCredentialsKey struct{}
+
+// UserCredentials are the credentials of the end user, which are forwarded to the gateway.
+type UserCredentials struct {
+	// BkToken is the login token of the user, usually from the bk_token cookie.
+	BkToken string
+	// BkTicket is the login ticket of the user, usually from the bk_ticket cookie.
+	BkTicket string
+	// JWT is the gateway JWT of the incoming request, usually from the X-Bkapi-Jwt header.
+	JWT string
+}
+
+// IsEmpty returns whether no credential is set.
+func (c UserCredentials) IsEmpty() bool {
+	return c.BkToken == "" && c.BkTicket == "" && c.JWT == ""
+}
+
+func (c UserCredentials) params() map[string]string {
+	params := make(map[string]string, 3)
+	for key, value := range map[string]string{
+		"bk_token":  c.BkToken,
+		"bk_ticket": c.BkTicket,
+		"jwt":       c.JWT,
+	} {
+		if value != "" {
+			params[key] = value
+		}
+	}
+
+	return params
+}
+
+// WithUserCredentials returns a context carrying the user credentials.
+func WithUserCredentials(ctx context.Context, credentials UserCredentials) context.Context {
+	return context.WithValue(ctx, userCredentialsKey{}, credentials)
+}
+
+// UserCredentialsFromContext returns the user credentials carried by the context.
+func UserCredentialsFromContext(ctx context.Context) (UserCredentials, bool) {
+	credentials, ok := ctx.Value(userCredentialsKey{}).(UserCredentials)
+	return credentials, ok
+}
+
+// mergeAuthorization merges the params into the authorization header value, the params take precedence.
+func mergeAuthorization(value string, params map[string]string) (string, error) {
+	authorization := make(map[string]interface{}, len(params))
+	if value != "" {
+		err := json.Unmarshal([]byte(value), &authorization)
+		if err != nil {
+			return "", define.ErrorWrapf(err, "failed to parse the authorization header")
+		}
+	}
+
+	for key, param := range params {
+		authorization[key] = param
+	}
+
+	merged, err := json.Marshal(authorization)
+	if err != nil {
+		return "", define.ErrorWrapf(err, "failed to marshal the authorization header")
+	}
+
+	return string(merged), nil
+}
+
+func forwardUserCredentials(next define.RoundTrip) define.RoundTrip {
+	return func(request *http.Request) (*http.Response, error) {
+		credentials, ok := UserCredentialsFromContext(request.Context())
+		if !ok || credentials.IsEmpty() {
+			return next(request)
+		}
+
+		value, err := mergeAuthorization(request.Header.Get(authorizationHeader), credentials.params())
+		if err != nil {
+			return nil, err
+		}
+
+		// the round tripper should not modify the original request
+		forwarded := request.Clone(request.Context())
+		forwarded.Header.Set(authorizationHeader, value)
+
+		return next(forwarded)
+	}
+}
+
+// OptForwardUserCredentials merges the user credentials carried by the operation context into
+// the X-Bkapi-Authorization header, the context is set by define.Operation.SetContext,
+// and the credentials are set by WithUserCredentials (e.g. by the gin_contrib middleware).
+// The operations without user credentials are not affected.
+func OptForwardUserCredentials() define.BkApiOption {
+	return internal.NewMiddlewareOption(forwardUserCredentials)
+}
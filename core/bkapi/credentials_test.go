@@ -0,0 +1,98 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal/mock"
+)
+
+var _ = Describe("UserCredentials", func() {
+	var (
+		ctrl          *gomock.Controller
+		roundTripper  *mock.MockRoundTripper
+		authorization map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		roundTripper = mock.NewMockRoundTripper(ctrl)
+		roundTripper.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			authorization = nil
+			Expect(json.Unmarshal([]byte(req.Header.Get("X-Bkapi-Authorization")), &authorization)).To(Succeed())
+
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+		}).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should carry the credentials by context", func() {
+		_, ok := bkapi.UserCredentialsFromContext(context.Background())
+		Expect(ok).To(BeFalse())
+
+		ctx := bkapi.WithUserCredentials(context.Background(), bkapi.UserCredentials{BkToken: "token"})
+		credentials, ok := bkapi.UserCredentialsFromContext(ctx)
+		Expect(ok).To(BeTrue())
+		Expect(credentials.BkToken).To(Equal("token"))
+		Expect(credentials.IsEmpty()).To(BeFalse())
+	})
+
+	DescribeTable("should forward the user credentials", func(backend define.Backend) {
+		client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+			Endpoint: "http://api.example.com/",
+			Backend:  backend,
+			AppCode:  "app",
+		}, bkapi.OptTransport(roundTripper), bkapi.OptForwardUserCredentials())
+		Expect(err).To(BeNil())
+
+		ctx := bkapi.WithUserCredentials(context.Background(), bkapi.UserCredentials{
+			BkTicket: "ticket",
+			JWT:      "jwt",
+		})
+		_, err = client.NewOperation(bkapi.OperationConfig{Method: "GET", Path: "/"}).SetContext(ctx).Request()
+		Expect(err).To(BeNil())
+
+		Expect(authorization).To(Equal(map[string]interface{}{
+			"bk_app_code": "app",
+			"bk_ticket":   "ticket",
+			"jwt":         "jwt",
+		}))
+	},
+		Entry("gentleman", define.BackendGentleman),
+		Entry("native", define.BackendNative),
+	)
+
+	It("should not modify the operation without user credentials", func() {
+		client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+			Endpoint: "http://api.example.com/",
+			AppCode:  "app",
+		}, bkapi.OptTransport(roundTripper), bkapi.OptForwardUserCredentials())
+		Expect(err).To(BeNil())
+
+		_, err = client.NewOperation(bkapi.OperationConfig{Method: "GET", Path: "/"}).Request()
+		Expect(err).To(BeNil())
+
+		Expect(authorization).To(Equal(map[string]interface{}{"bk_app_code": "app"}))
+	})
+})
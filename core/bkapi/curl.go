@@ -0,0 +1,24 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi
+
+import (
+	"net/http"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+// CurlCommand renders a request, which usually built by define.Operation.Build, as a curl command.
+// The secrets in the request headers will be masked when maskSecrets is true.
+func CurlCommand(request *http.Request, maskSecrets bool) (string, error) {
+	return internal.NewCurlCommand(request, maskSecrets)
+}
@@ -0,0 +1,134 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi
+
+import (
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+// the runtime debug pattern which overrides the client configs, nil means not overridden
+var debugOverride atomic.Value
+
+// the debug files shared by the clients
+var (
+	debugFilesLock sync.Mutex
+	debugFiles     = make(map[string]io.Writer)
+)
+
+// SetDebug enables the wire dump for the clients matching the pattern at runtime, it takes effect on
+// the operations created afterwards, and overrides the Debug config (BKAPI_DEBUG env) of all the clients.
+// An empty pattern disables the dump, see ClientConfig.Debug for the pattern syntax.
+func SetDebug(pattern string) {
+	debugOverride.Store(&pattern)
+}
+
+// ResetDebug removes the runtime debug pattern, so the Debug config of each client takes effect again.
+func ResetDebug() {
+	debugOverride.Store((*string)(nil))
+}
+
+// matchDebugPattern reports whether the client name matches the comma separated globs,
+// "1", "true" and "*" match all the clients.
+func matchDebugPattern(pattern, name string) bool {
+	for _, glob := range strings.Split(pattern, ",") {
+		glob = strings.TrimSpace(glob)
+		switch strings.ToLower(glob) {
+		case "", "0", "false":
+			continue
+		case "1", "true", "*":
+			return true
+		}
+
+		matched, err := path.Match(glob, name)
+		if err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func openDebugFile(filename string) (io.Writer, error) {
+	debugFilesLock.Lock()
+	defer debugFilesLock.Unlock()
+
+	writer, ok := debugFiles[filename]
+	if ok {
+		return writer, nil
+	}
+
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	debugFiles[filename] = file
+
+	return file, nil
+}
+
+func debugOptions(config define.ClientConfig) []define.BkApiClientOption {
+	var (
+		pattern   string
+		filename  string
+		bodyLimit int
+	)
+
+	debugConfig, ok := config.(define.DebugConfig)
+	if ok {
+		pattern = debugConfig.GetDebug()
+		filename = debugConfig.GetDebugFile()
+		bodyLimit = debugConfig.GetDebugBodyLimit()
+	}
+
+	logger := config.GetLogger()
+
+	var writer io.Writer
+	if filename != "" {
+		var err error
+		writer, err = openDebugFile(filename)
+		// the debugging should not break the client, so fallback to the logger
+		if err != nil && logger != nil {
+			logger.Warn("failed to open the debug file, dump to the logger instead", map[string]interface{}{
+				"file":  filename,
+				"error": err.Error(),
+			})
+		}
+	}
+
+	dumper := internal.NewWireDumper(writer, logger, bodyLimit)
+	middleware := internal.NewTransportMiddlewareOption(dumper.Middleware)
+
+	// the switch is checked when creating the operations, so the disabled dumper costs nothing on the requests
+	return []define.BkApiClientOption{
+		NewOperationOption(func(op define.Operation) error {
+			currentPattern := pattern
+			if override, ok := debugOverride.Load().(*string); ok && override != nil {
+				currentPattern = *override
+			}
+
+			if currentPattern == "" || !matchDebugPattern(currentPattern, config.GetName()) {
+				return nil
+			}
+
+			return middleware.ApplyToOperation(op)
+		}),
+	}
+}
@@ -0,0 +1,111 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+var _ = Describe("Debug", func() {
+	var (
+		server    *httptest.Server
+		debugFile string
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"result":true}`))
+		}))
+		debugFile = filepath.Join(GinkgoT().TempDir(), "debug.log")
+	})
+
+	AfterEach(func() {
+		bkapi.ResetDebug()
+		server.Close()
+	})
+
+	newClient := func(name, pattern string) define.BkApiClient {
+		env := map[string]string{
+			"BKAPI_DEBUG":      pattern,
+			"BKAPI_DEBUG_FILE": debugFile,
+		}
+
+		client, err := bkapi.NewBkApiClient(name, bkapi.ClientConfig{
+			Endpoint:  server.URL,
+			AppCode:   "app",
+			AppSecret: "s3cr3t",
+			Getenv: func(key string) string {
+				return env[key]
+			},
+		})
+		Expect(err).To(BeNil())
+
+		return client
+	}
+
+	request := func(client define.BkApiClient) {
+		_, err := client.NewOperation(bkapi.OperationConfig{Name: "hello", Method: "GET", Path: "/hello"}).Request()
+		Expect(err).To(BeNil())
+	}
+
+	readDump := func() string {
+		content, err := os.ReadFile(debugFile)
+		if os.IsNotExist(err) {
+			return ""
+		}
+		Expect(err).To(BeNil())
+
+		return string(content)
+	}
+
+	It("should dump the traffic of the matched clients by env", func() {
+		request(newClient("testing", "other,test*"))
+
+		dump := readDump()
+		Expect(dump).To(ContainSubstring(">>> bkapi request testing.api.hello"))
+		Expect(dump).To(ContainSubstring("GET /hello HTTP/1.1"))
+		Expect(dump).To(ContainSubstring(`{"result":true}`))
+		Expect(dump).NotTo(ContainSubstring("s3cr3t"))
+	})
+
+	It("should not dump the traffic of the unmatched clients", func() {
+		request(newClient("testing", "other"))
+
+		Expect(readDump()).To(BeEmpty())
+	})
+
+	It("should switch the dump at runtime", func() {
+		client := newClient("testing", "")
+
+		bkapi.SetDebug("1")
+		request(client)
+		Expect(readDump()).To(ContainSubstring(">>> bkapi request testing.api.hello"))
+
+		bkapi.SetDebug("")
+		Expect(os.Truncate(debugFile, 0)).To(Succeed())
+		request(client)
+		Expect(readDump()).To(BeEmpty())
+
+		bkapi.ResetDebug()
+		request(client)
+		Expect(readDump()).To(BeEmpty())
+	})
+})
@@ -13,115 +13,195 @@ package bkapi
 
 import (
 	"crypto/tls"
+	"net"
 	"net/http"
+	"net/url"
 	"time"
 
-	"gopkg.in/h2non/gentleman.v2/plugins/auth"
-	"gopkg.in/h2non/gentleman.v2/plugins/cookies"
-	"gopkg.in/h2non/gentleman.v2/plugins/headers"
-	"gopkg.in/h2non/gentleman.v2/plugins/proxy"
-	"gopkg.in/h2non/gentleman.v2/plugins/query"
 	"gopkg.in/h2non/gentleman.v2/plugins/redirect"
 	"gopkg.in/h2non/gentleman.v2/plugins/timeout"
-	tlsplugin "gopkg.in/h2non/gentleman.v2/plugins/tls"
-	"gopkg.in/h2non/gentleman.v2/plugins/transport"
 
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
 )
 
+// the options in this file work on all the backends,
+// some of them keep the gentleman plugins to keep the original behaviors of the gentleman backend.
+
+func transportHookOption(fn func(transport *http.Transport)) *internal.BackendOption {
+	return internal.NewTransportHookOption(fn)
+}
+
+func headerHookOption(fn func(header http.Header)) *internal.BackendOption {
+	return internal.NewRequestHookOption(func(request *http.Request) error {
+		fn(request.Header)
+		return nil
+	})
+}
+
+func queryHookOption(fn func(query url.Values)) *internal.BackendOption {
+	return internal.NewRequestHookOption(func(request *http.Request) error {
+		query := request.URL.Query()
+		fn(query)
+		request.URL.RawQuery = query.Encode()
+
+		return nil
+	})
+}
+
 // OptTimeout defines the maximum amount of time a whole request process
 // (including dial / request / redirect) can take.
 func OptTimeout(duration time.Duration) define.BkApiOption {
-	return internal.NewPluginOption(timeout.Request(duration))
+	return internal.NewHttpClientHookOption(func(client *http.Client) error {
+		client.Timeout = duration
+		return nil
+	})
 }
 
 // OptDialTimeout defines the maximum amount of time waiting for network dialing
 func OptDialTimeout(duration, keepAlive time.Duration) define.BkApiOption {
-	return internal.NewPluginOption(timeout.Dial(duration, keepAlive))
+	return transportHookOption(func(transport *http.Transport) {
+		transport.DialContext = (&net.Dialer{
+			Timeout:   duration,
+			KeepAlive: keepAlive,
+		}).DialContext
+	}).WithPlugin(timeout.Dial(duration, keepAlive))
 }
 
 // OptTLShandshakeTimeout defines the maximum amount of time waiting for a TLS handshake
 func OptTLShandshakeTimeout(duration time.Duration) define.BkApiOption {
-	return internal.NewPluginOption(timeout.TLS(duration))
+	return transportHookOption(func(transport *http.Transport) {
+		transport.TLSHandshakeTimeout = duration
+	}).WithPlugin(timeout.TLS(duration))
 }
 
 // OptBasicAuth defines an authorization basic header in the outgoing request
 func OptBasicAuth(username, password string) define.BkApiOption {
-	return internal.NewPluginOption(auth.Basic(username, password))
+	return internal.NewRequestHookOption(func(request *http.Request) error {
+		request.SetBasicAuth(username, password)
+		return nil
+	})
 }
 
 // OptBearerAuth defines an authorization bearer token header in the outgoing request
 func OptBearerAuth(token string) define.BkApiOption {
-	return internal.NewPluginOption(auth.Bearer(token))
+	return headerHookOption(func(header http.Header) {
+		header.Set("Authorization", "Bearer "+token)
+	})
 }
 
 // OptAddCookie adds a cookie to the request. Per RFC 6265 section 5.4, AddCookie does not
 // attach more than one Cookie header field.
 // That means all cookies, if any, are written into the same line, separated by semicolon.
 func OptAddCookie(cookie *http.Cookie) define.BkApiOption {
-	return internal.NewPluginOption(cookies.Add(cookie))
+	return internal.NewRequestHookOption(func(request *http.Request) error {
+		request.AddCookie(cookie)
+		return nil
+	})
 }
 
 // OptDelAllCookies deletes all the cookies by deleting the Cookie header field.
 func OptDelAllCookies() define.BkApiOption {
-	return internal.NewPluginOption(cookies.DelAll())
+	return headerHookOption(func(header http.Header) {
+		header.Del("Cookie")
+	})
 }
 
 // OptSetRequestHeader sets the header entries associated with key to the single element value.
 // It replaces any existing values associated with key.
 func OptSetRequestHeader(key string, value string) define.BkApiOption {
-	return internal.NewPluginOption(headers.Set(key, value))
+	return headerHookOption(func(header http.Header) {
+		header.Set(key, value)
+	})
 }
 
 // OptDelRequestHeader deletes the header fields associated with key.
 func OptDelRequestHeader(key string) define.BkApiOption {
-	return internal.NewPluginOption(headers.Del(key))
+	return headerHookOption(func(header http.Header) {
+		header.Del(key)
+	})
 }
 
 // OptSetRequestHeaders sets the headers.
-func OptSetRequestHeaders(header map[string]string) define.BkApiOption {
-	return internal.NewPluginOption(headers.SetMap(header))
+func OptSetRequestHeaders(headers map[string]string) define.BkApiOption {
+	return headerHookOption(func(header http.Header) {
+		for key, value := range headers {
+			header.Set(key, value)
+		}
+	})
 }
 
 // OptProxies defines the proxy servers to be used based on the transport scheme
 func OptProxies(servers map[string]string) define.BkApiOption {
-	return internal.NewPluginOption(proxy.Set(servers))
+	return transportHookOption(func(transport *http.Transport) {
+		transport.Proxy = func(request *http.Request) (*url.URL, error) {
+			if value, ok := servers[request.URL.Scheme]; ok {
+				return url.Parse(value)
+			}
+
+			return http.ProxyFromEnvironment(request)
+		}
+	})
 }
 
 // OptSetRequestQueryParam ets the query param key and value.
 // It replaces any existing values.
 func OptSetRequestQueryParam(key string, value string) define.BkApiOption {
-	return internal.NewPluginOption(query.Set(key, value))
+	return queryHookOption(func(query url.Values) {
+		query.Set(key, value)
+	})
 }
 
 // OptAddRequestQueryParam adds the query param value to key.
 // It appends to any existing values associated with key.
 func OptAddRequestQueryParam(key string, value string) define.BkApiOption {
-	return internal.NewPluginOption(query.Add(key, value))
+	return queryHookOption(func(query url.Values) {
+		query.Add(key, value)
+	})
 }
 
 // OptDelRequestQueryParam deletes the query param values associated with key.
 func OptDelRequestQueryParam(key string) define.BkApiOption {
-	return internal.NewPluginOption(query.Del(key))
+	return queryHookOption(func(query url.Values) {
+		query.Del(key)
+	})
 }
 
 // OptSetRequestQueryParams sets the query params.
 func OptSetRequestQueryParams(params map[string]string) define.BkApiOption {
-	return internal.NewPluginOption(query.SetMap(params))
+	return queryHookOption(func(query url.Values) {
+		for key, value := range params {
+			query.Set(key, value)
+		}
+	})
 }
 
 // OptLimitRedirect defines in the maximum number of redirects that http.Client should follow.
 func OptLimitRedirect(limit int) define.BkApiOption {
-	return internal.NewPluginOption(redirect.Limit(limit))
+	return internal.NewHttpClientHookOption(func(client *http.Client) error {
+		client.CheckRedirect = func(request *http.Request, via []*http.Request) error {
+			if len(via) >= limit {
+				return redirect.ErrRedirectLimitExceeded
+			}
+
+			return nil
+		}
+
+		return nil
+	}).WithPlugin(redirect.Limit(limit))
 }
 
 // OptTransport sets a new HTTP transport for the outgoing request
 func OptTransport(roundTripper http.RoundTripper) define.BkApiOption {
-	return internal.NewPluginOption(transport.Set(roundTripper))
+	return internal.NewHttpClientHookOption(func(client *http.Client) error {
+		client.Transport = roundTripper
+		return nil
+	})
 }
 
 // OptTLS defines the request TLS connection config
 func OptTLS(config *tls.Config) define.BkApiOption {
-	return internal.NewPluginOption(tlsplugin.Config(config))
+	return transportHookOption(func(transport *http.Transport) {
+		transport.TLSClientConfig = config
+	})
 }
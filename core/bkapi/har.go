@@ -0,0 +1,570 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+const (
+	// DefaultHarCapacity is the default number of the entries kept by HarRecorder.
+	DefaultHarCapacity = 100
+	// DefaultHarBodyLimit is the default maximum bytes of the bodies recorded by HarRecorder.
+	DefaultHarBodyLimit = 64 * 1024
+
+	harVersion = "1.2"
+)
+
+// HarArchive is the root of a HAR 1.2 file.
+type HarArchive struct {
+	Log HarLog `json:"log"`
+}
+
+// HarLog is the log of a HAR file.
+type HarLog struct {
+	Version string     `json:"version"`
+	Creator HarCreator `json:"creator"`
+	Entries []HarEntry `json:"entries"`
+}
+
+// HarCreator is the application which creates the HAR file.
+type HarCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HarEntry is an exported HTTP request.
+type HarEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HarRequest  `json:"request"`
+	Response        HarResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HarTimings  `json:"timings"`
+	ServerIPAddress string      `json:"serverIPAddress,omitempty"`
+	Comment         string      `json:"comment,omitempty"`
+	// Operation is the full name of the operation, a custom field.
+	Operation string `json:"_operation,omitempty"`
+	// BkApiRequestId is the X-Bkapi-Request-Id header of the response, a custom field.
+	BkApiRequestId string `json:"_bkapiRequestId,omitempty"`
+}
+
+// HarNameValue is a header, query parameter or cookie.
+type HarNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HarPostData is the request body.
+type HarPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// HarRequest is the detail of a request.
+type HarRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HarNameValue `json:"cookies"`
+	Headers     []HarNameValue `json:"headers"`
+	QueryString []HarNameValue `json:"queryString"`
+	PostData    *HarPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HarContent is the response body.
+type HarContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// HarResponse is the detail of a response.
+type HarResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HarNameValue `json:"cookies"`
+	Headers     []HarNameValue `json:"headers"`
+	Content     HarContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+	Comment     string         `json:"comment,omitempty"`
+}
+
+// HarTimings are the durations in milliseconds of the request phases, -1 means not applicable.
+type HarTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// harTrace collects the timestamps of a request by httptrace.
+type harTrace struct {
+	lock          sync.Mutex
+	dnsStart      time.Time
+	dnsDone       time.Time
+	connectStart  time.Time
+	connectDone   time.Time
+	tlsStart      time.Time
+	tlsDone       time.Time
+	gotConn       time.Time
+	wroteRequest  time.Time
+	firstByte     time.Time
+	serverAddress string
+}
+
+func (t *harTrace) record(field *time.Time) func() {
+	return func() {
+		t.lock.Lock()
+		*field = time.Now()
+		t.lock.Unlock()
+	}
+}
+
+func (t *harTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { t.record(&t.dnsStart)() },
+		DNSDone:           func(httptrace.DNSDoneInfo) { t.record(&t.dnsDone)() },
+		ConnectStart:      func(string, string) { t.record(&t.connectStart)() },
+		ConnectDone:       func(string, string, error) { t.record(&t.connectDone)() },
+		TLSHandshakeStart: t.record(&t.tlsStart),
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.record(&t.tlsDone)()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.record(&t.gotConn)()
+
+			if info.Conn == nil {
+				return
+			}
+
+			host, _, err := net.SplitHostPort(info.Conn.RemoteAddr().String())
+			if err == nil {
+				t.lock.Lock()
+				t.serverAddress = host
+				t.lock.Unlock()
+			}
+		},
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.record(&t.wroteRequest)() },
+		GotFirstResponseByte: t.record(&t.firstByte),
+	}
+}
+
+func harDuration(start, end time.Time) float64 {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return -1
+	}
+
+	return float64(end.Sub(start)) / float64(time.Millisecond)
+}
+
+func nonNegative(value float64) float64 {
+	if value < 0 {
+		return 0
+	}
+
+	return value
+}
+
+// timings splits the total time into the HAR phases, the sum of the phases equals the entry time.
+func (t *harTrace) timings(startedAt, finishedAt time.Time) HarTimings {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	timings := HarTimings{
+		DNS:     harDuration(t.dnsStart, t.dnsDone),
+		Connect: harDuration(t.connectStart, t.connectDone),
+		SSL:     harDuration(t.tlsStart, t.tlsDone),
+	}
+
+	// connect includes ssl in HAR
+	if timings.SSL >= 0 && timings.Connect >= 0 {
+		timings.Connect += timings.SSL
+	} else if timings.SSL >= 0 {
+		timings.Connect = timings.SSL
+	}
+
+	gotConn := t.gotConn
+	if gotConn.IsZero() {
+		gotConn = startedAt
+	}
+	wroteRequest := t.wroteRequest
+	if wroteRequest.IsZero() {
+		wroteRequest = gotConn
+	}
+	firstByte := t.firstByte
+	if firstByte.IsZero() {
+		firstByte = wroteRequest
+	}
+
+	timings.Blocked = nonNegative(harDuration(startedAt, gotConn) -
+		nonNegative(timings.DNS) - nonNegative(timings.Connect))
+	timings.Send = nonNegative(harDuration(gotConn, wroteRequest))
+	timings.Wait = nonNegative(harDuration(wroteRequest, firstByte))
+	timings.Receive = nonNegative(harDuration(firstByte, finishedAt))
+
+	return timings
+}
+
+func harNameValues(values map[string][]string) []HarNameValue {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]HarNameValue, 0, len(values))
+	for _, key := range keys {
+		for _, value := range values[key] {
+			result = append(result, HarNameValue{Name: key, Value: value})
+		}
+	}
+
+	return result
+}
+
+// HarRecorder records the traffic of the operations into a ring buffer, and exports them in HAR 1.2 format.
+// The sensitive headers and query parameters are masked, so the HAR files can be shared for troubleshooting.
+type HarRecorder struct {
+	capacity  int
+	bodyLimit int
+
+	lock    sync.Mutex
+	entries []HarEntry
+	next    int
+}
+
+// NewHarRecorder creates a HarRecorder keeping the latest capacity entries,
+// the bodies longer than bodyLimit bytes are truncated. Zero values mean the defaults.
+func NewHarRecorder(capacity, bodyLimit int) *HarRecorder {
+	if capacity <= 0 {
+		capacity = DefaultHarCapacity
+	}
+
+	if bodyLimit <= 0 {
+		bodyLimit = DefaultHarBodyLimit
+	}
+
+	return &HarRecorder{
+		capacity:  capacity,
+		bodyLimit: bodyLimit,
+		entries:   make([]HarEntry, 0, capacity),
+	}
+}
+
+func (r *HarRecorder) add(entry HarEntry) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.entries) < r.capacity {
+		r.entries = append(r.entries, entry)
+		return
+	}
+
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % r.capacity
+}
+
+// Entries returns the recorded entries, from the oldest to the latest.
+func (r *HarRecorder) Entries() []HarEntry {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	entries := make([]HarEntry, 0, len(r.entries))
+	entries = append(entries, r.entries[r.next:]...)
+
+	return append(entries, r.entries[:r.next]...)
+}
+
+// Reset removes all the recorded entries.
+func (r *HarRecorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.entries = r.entries[:0]
+	r.next = 0
+}
+
+// take removes and returns the recorded entries in one step, so no entry is recorded in between.
+func (r *HarRecorder) take() []HarEntry {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	entries := make([]HarEntry, 0, len(r.entries))
+	entries = append(entries, r.entries[r.next:]...)
+	entries = append(entries, r.entries[:r.next]...)
+
+	r.entries = make([]HarEntry, 0, r.capacity)
+	r.next = 0
+
+	return entries
+}
+
+// restore puts the taken entries back before the ones recorded since, the oldest ones are dropped when full.
+func (r *HarRecorder) restore(entries []HarEntry) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	entries = append(entries, r.entries[r.next:]...)
+	entries = append(entries, r.entries[:r.next]...)
+	if len(entries) > r.capacity {
+		entries = entries[len(entries)-r.capacity:]
+	}
+
+	r.entries = append(make([]HarEntry, 0, r.capacity), entries...)
+	r.next = 0
+}
+
+// Archive returns the recorded entries as a HAR archive.
+func (r *HarRecorder) Archive() *HarArchive {
+	return newHarArchive(r.Entries())
+}
+
+func newHarArchive(entries []HarEntry) *HarArchive {
+	return &HarArchive{
+		Log: HarLog{
+			Version: harVersion,
+			Creator: HarCreator{Name: define.UserAgent, Version: define.Version},
+			Entries: entries,
+		},
+	}
+}
+
+// WriteTo writes the HAR archive as JSON.
+func (r *HarRecorder) WriteTo(writer io.Writer) (int64, error) {
+	return writeHarArchive(writer, r.Archive())
+}
+
+func writeHarArchive(writer io.Writer, archive *HarArchive) (int64, error) {
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return 0, define.ErrorWrapf(err, "failed to marshal HAR")
+	}
+
+	written, err := writer.Write(data)
+
+	return int64(written), err
+}
+
+// Flush writes the HAR archive to the file and removes the written entries,
+// the entries recorded during the writing are kept for the next flush.
+// The entries are kept as well when the writing fails.
+func (r *HarRecorder) Flush(filename string) error {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return define.ErrorWrapf(err, "failed to open HAR file %s", filename)
+	}
+
+	entries := r.take()
+	_, err = writeHarArchive(file, newHarArchive(entries))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		r.restore(entries)
+		return define.ErrorWrapf(err, "failed to write HAR file %s", filename)
+	}
+
+	return nil
+}
+
+// truncate returns the text of the body, which is base64 encoded when it is not valid utf-8.
+func (r *HarRecorder) truncate(body []byte) (text, encoding, comment string) {
+	if len(body) > r.bodyLimit {
+		body = body[:r.bodyLimit]
+		comment = "truncated"
+	}
+
+	if utf8.Valid(body) {
+		return string(body), "", comment
+	}
+
+	return base64.StdEncoding.EncodeToString(body), "base64", comment
+}
+
+func (r *HarRecorder) newRequest(request *http.Request, body []byte) HarRequest {
+	requestUrl := *request.URL
+	query := internal.MaskQuery(request.URL.Query())
+	requestUrl.RawQuery = query.Encode()
+
+	proto := request.Proto
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+
+	harRequest := HarRequest{
+		Method:      request.Method,
+		URL:         requestUrl.String(),
+		HTTPVersion: proto,
+		Cookies:     []HarNameValue{},
+		Headers:     harNameValues(internal.MaskHeader(request.Header)),
+		QueryString: harNameValues(query),
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+
+	if len(body) > 0 {
+		text, _, comment := r.truncate(body)
+		harRequest.PostData = &HarPostData{
+			MimeType: request.Header.Get("Content-Type"),
+			Text:     text,
+			Comment:  comment,
+		}
+	}
+
+	return harRequest
+}
+
+// newResponse records the response, size is the length of the whole body, -1 means unknown.
+func (r *HarRecorder) newResponse(response *http.Response, body []byte, size int) HarResponse {
+	text, encoding, comment := r.truncate(body)
+
+	return HarResponse{
+		Status:      response.StatusCode,
+		StatusText:  http.StatusText(response.StatusCode),
+		HTTPVersion: response.Proto,
+		Cookies:     []HarNameValue{},
+		Headers:     harNameValues(internal.MaskHeader(response.Header)),
+		Content: HarContent{
+			Size:     size,
+			MimeType: response.Header.Get("Content-Type"),
+			Text:     text,
+			Encoding: encoding,
+			Comment:  comment,
+		},
+		RedirectURL: response.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    size,
+	}
+}
+
+// prefixedBody streams the read prefix and then the rest of the body.
+type prefixedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// readResponseBody reads at most limit+1 bytes of the body to record, the body is still streamed to the caller.
+// The size is the length of the whole body, -1 means unknown because the body is not read to the end.
+func readResponseBody(response *http.Response, limit int) (prefix []byte, size int, err error) {
+	if response.Body == nil || response.Body == http.NoBody {
+		return nil, 0, nil
+	}
+
+	prefix, err = io.ReadAll(io.LimitReader(response.Body, int64(limit)+1))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	size = len(prefix)
+	if size > limit {
+		size = -1
+		if response.ContentLength >= 0 {
+			size = int(response.ContentLength)
+		}
+	}
+
+	response.Body = prefixedBody{
+		Reader: io.MultiReader(bytes.NewReader(prefix), response.Body),
+		Closer: response.Body,
+	}
+
+	return prefix, size, nil
+}
+
+func (r *HarRecorder) middleware(next define.RoundTrip) define.RoundTrip {
+	return func(request *http.Request) (*http.Response, error) {
+		metadata, _ := define.OperationMetadataFromContext(request.Context())
+
+		requestBody, err := internal.ReadRequestBody(request)
+		if err != nil {
+			return nil, define.ErrorWrapf(err, "failed to read body to record")
+		}
+
+		trace := &harTrace{}
+		traced := request.WithContext(httptrace.WithClientTrace(request.Context(), trace.clientTrace()))
+
+		startedAt := time.Now()
+		response, err := next(traced)
+
+		entry := HarEntry{
+			StartedDateTime: startedAt.Format(time.RFC3339Nano),
+			Request:         r.newRequest(request, requestBody),
+			Operation:       metadata.FullName,
+		}
+
+		var (
+			responseBody []byte
+			size         int
+		)
+		if err == nil {
+			responseBody, size, err = readResponseBody(response, r.bodyLimit)
+			if err != nil {
+				// a round trip should not return both the response and the error
+				response.Body.Close()
+				response = nil
+				err = define.ErrorWrapf(err, "failed to read response body")
+			}
+		}
+
+		if err != nil {
+			// the failed requests are recorded with status 0, like the browsers do
+			entry.Response = HarResponse{
+				Cookies: []HarNameValue{}, Headers: []HarNameValue{},
+				HeadersSize: -1, BodySize: -1, Comment: err.Error(),
+			}
+		} else {
+			entry.Response = r.newResponse(response, responseBody, size)
+			entry.BkApiRequestId = response.Header.Get("X-Bkapi-Request-Id")
+		}
+
+		finishedAt := time.Now()
+		entry.Time = harDuration(startedAt, finishedAt)
+		entry.Timings = trace.timings(startedAt, finishedAt)
+		entry.ServerIPAddress = trace.serverAddress
+
+		r.add(entry)
+
+		return response, err
+	}
+}
+
+// OptRecordHar records the traffic of the operations by the recorder, each attempt of the retries is an entry.
+func OptRecordHar(recorder *HarRecorder) define.BkApiOption {
+	return internal.NewMiddlewareOption(recorder.middleware)
+}
@@ -0,0 +1,226 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+var _ = Describe("HarRecorder", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Bkapi-Request-Id", "request-id")
+			_, _ = w.Write([]byte(`{"result":true}`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newClient := func(backend define.Backend, recorder *bkapi.HarRecorder) define.BkApiClient {
+		client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+			Endpoint:  server.URL,
+			AppCode:   "app",
+			AppSecret: "s3cr3t",
+			Backend:   backend,
+		}, bkapi.OptRecordHar(recorder))
+		Expect(err).To(BeNil())
+
+		return client
+	}
+
+	request := func(client define.BkApiClient, path string) {
+		response, err := client.NewOperation(
+			bkapi.OperationConfig{Name: "hello", Method: "POST", Path: path},
+			bkapi.OptSetRequestQueryParam("access_token", "t0ken"),
+			bkapi.OptJsonBodyProvider(),
+		).SetBody(map[string]string{"hello": "world"}).Request()
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+	}
+
+	DescribeTable("should record the operations", func(backend define.Backend) {
+		recorder := bkapi.NewHarRecorder(0, 0)
+		request(newClient(backend, recorder), "/hello")
+
+		entries := recorder.Entries()
+		Expect(entries).To(HaveLen(1))
+
+		entry := entries[0]
+		Expect(entry.Operation).To(Equal("testing.api.hello"))
+		Expect(entry.BkApiRequestId).To(Equal("request-id"))
+		Expect(entry.Time).To(BeNumerically(">=", 0))
+		Expect(entry.ServerIPAddress).To(Equal("127.0.0.1"))
+
+		Expect(entry.Request.Method).To(Equal("POST"))
+		Expect(entry.Request.URL).To(ContainSubstring("/hello"))
+		Expect(entry.Request.URL).NotTo(ContainSubstring("t0ken"))
+		Expect(entry.Request.PostData.Text).To(MatchJSON(`{"hello":"world"}`))
+
+		Expect(entry.Response.Status).To(Equal(http.StatusOK))
+		Expect(entry.Response.Content.MimeType).To(Equal("application/json"))
+		Expect(entry.Response.Content.Text).To(Equal(`{"result":true}`))
+
+		data, err := json.Marshal(entry.Request.Headers)
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(ContainSubstring("X-Bkapi-Authorization"))
+		Expect(string(data)).NotTo(ContainSubstring("s3cr3t"))
+	},
+		Entry("gentleman", define.BackendGentleman),
+		Entry("native", define.BackendNative),
+	)
+
+	It("should keep the latest entries", func() {
+		recorder := bkapi.NewHarRecorder(2, 0)
+		client := newClient(define.BackendNative, recorder)
+
+		for _, path := range []string{"/first", "/second", "/third"} {
+			request(client, path)
+		}
+
+		entries := recorder.Entries()
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].Request.URL).To(ContainSubstring("/second"))
+		Expect(entries[1].Request.URL).To(ContainSubstring("/third"))
+
+		recorder.Reset()
+		Expect(recorder.Entries()).To(BeEmpty())
+	})
+
+	It("should truncate the bodies", func() {
+		recorder := bkapi.NewHarRecorder(0, 4)
+		request(newClient(define.BackendNative, recorder), "/hello")
+
+		content := recorder.Entries()[0].Response.Content
+		Expect(content.Text).To(Equal(`{"re`))
+		Expect(content.Size).To(Equal(len(`{"result":true}`)))
+		Expect(content.Comment).To(Equal("truncated"))
+	})
+
+	It("should stream the whole body to the caller", func() {
+		largeBody := strings.Repeat("x", 1024)
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// flush before writing the body, so the response is chunked and the size is unknown
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte(largeBody))
+		})
+
+		recorder := bkapi.NewHarRecorder(0, 8)
+		response, err := newClient(define.BackendNative, recorder).NewOperation(
+			bkapi.OperationConfig{Method: "GET", Path: "/download"},
+		).Request()
+		Expect(err).To(BeNil())
+		defer response.Body.Close()
+
+		body, err := io.ReadAll(response.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(Equal(largeBody))
+
+		content := recorder.Entries()[0].Response.Content
+		Expect(content.Text).To(Equal(largeBody[:8]))
+		Expect(content.Size).To(Equal(-1))
+		Expect(content.Comment).To(Equal("truncated"))
+	})
+
+	It("should not return the response with the error when failed to read the body", func() {
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the connection is closed before the declared length is written
+			w.Header().Set("Content-Length", "100")
+			_, _ = w.Write([]byte("partial"))
+		})
+
+		var (
+			response *http.Response
+			err      error
+		)
+		recorder := bkapi.NewHarRecorder(0, 0)
+		client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+			Endpoint: server.URL,
+			Backend:  define.BackendNative,
+		}, bkapi.OptMiddleware(func(next define.RoundTrip) define.RoundTrip {
+			return func(request *http.Request) (*http.Response, error) {
+				response, err = next(request)
+				return response, err
+			}
+		}), bkapi.OptRecordHar(recorder))
+		Expect(err).To(BeNil())
+
+		_, _ = client.NewOperation(bkapi.OperationConfig{Method: "GET", Path: "/"}).Request()
+		Expect(err).NotTo(BeNil())
+		Expect(response).To(BeNil())
+
+		entry := recorder.Entries()[0]
+		Expect(entry.Response.Status).To(Equal(0))
+		Expect(entry.Response.Comment).To(ContainSubstring("failed to read response body"))
+	})
+
+	It("should write a HAR 1.2 archive", func() {
+		recorder := bkapi.NewHarRecorder(0, 0)
+		request(newClient(define.BackendNative, recorder), "/hello")
+
+		var buffer bytes.Buffer
+		_, err := recorder.WriteTo(&buffer)
+		Expect(err).To(BeNil())
+
+		var archive map[string]map[string]interface{}
+		Expect(json.Unmarshal(buffer.Bytes(), &archive)).To(Succeed())
+		Expect(archive["log"]["version"]).To(Equal("1.2"))
+		Expect(archive["log"]["creator"]).To(HaveKey("name"))
+		Expect(archive["log"]["entries"]).To(HaveLen(1))
+	})
+
+	It("should flush to the file", func() {
+		recorder := bkapi.NewHarRecorder(0, 0)
+		request(newClient(define.BackendNative, recorder), "/hello")
+
+		filename := filepath.Join(GinkgoT().TempDir(), "bkapi.har")
+		Expect(recorder.Flush(filename)).To(Succeed())
+		Expect(recorder.Entries()).To(BeEmpty())
+
+		content, err := os.ReadFile(filename)
+		Expect(err).To(BeNil())
+
+		var archive bkapi.HarArchive
+		Expect(json.Unmarshal(content, &archive)).To(Succeed())
+		Expect(archive.Log.Entries).To(HaveLen(1))
+		Expect(archive.Log.Entries[0].BkApiRequestId).To(Equal("request-id"))
+	})
+
+	It("should keep the entries when the flush fails", func() {
+		if _, err := os.Stat("/dev/full"); err != nil {
+			Skip("/dev/full is not available")
+		}
+
+		recorder := bkapi.NewHarRecorder(0, 0)
+		request(newClient(define.BackendNative, recorder), "/hello")
+
+		Expect(recorder.Flush("/dev/full")).NotTo(Succeed())
+		Expect(recorder.Entries()).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,75 @@
+//go:build !windows
+
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+)
+
+var _ = Describe("HarRecorder Flush", func() {
+	It("should keep the entries recorded during the flush", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(strings.Repeat("a", 128*1024)))
+		}))
+		defer server.Close()
+
+		recorder := bkapi.NewHarRecorder(0, 256*1024)
+		client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{Endpoint: server.URL},
+			bkapi.OptRecordHar(recorder))
+		Expect(err).To(BeNil())
+
+		request := func() {
+			response, err := client.NewOperation(bkapi.OperationConfig{Method: "GET", Path: "/"}).Request()
+			Expect(err).To(BeNil())
+			Expect(response.Body.Close()).To(Succeed())
+		}
+		request()
+
+		// the writing to a fifo blocks until it is read
+		filename := filepath.Join(GinkgoT().TempDir(), "bkapi.har")
+		Expect(syscall.Mkfifo(filename, 0o600)).To(Succeed())
+
+		flushed := make(chan error, 1)
+		go func() {
+			flushed <- recorder.Flush(filename)
+		}()
+
+		reader, err := os.Open(filename)
+		Expect(err).To(BeNil())
+		defer reader.Close()
+
+		// the entries have been taken once the archive is being written, and the writing is not finished,
+		// as the archive is larger than the pipe buffer
+		_, err = reader.Read(make([]byte, 1))
+		Expect(err).To(BeNil())
+		request()
+
+		_, err = io.Copy(io.Discard, reader)
+		Expect(err).To(BeNil())
+		Expect(<-flushed).To(Succeed())
+
+		Expect(recorder.Entries()).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,303 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+const (
+	// DefaultHealthWindow is the default rolling window of HealthTracker.
+	DefaultHealthWindow = time.Minute
+	// DefaultHealthBuckets is the default number of the buckets in the window.
+	DefaultHealthBuckets = 6
+	// DefaultHealthMinRequests is the default minimum requests in the window to evaluate the objectives.
+	DefaultHealthMinRequests = 10
+	// DefaultHealthMinSuccessRate is the default objective of the success rate.
+	DefaultHealthMinSuccessRate = 0.99
+	// DefaultHealthLatencySamples is the default maximum latency samples kept by each bucket.
+	DefaultHealthLatencySamples = 1000
+)
+
+// HealthTrackerConfig is the config of HealthTracker, zero values mean the defaults.
+type HealthTrackerConfig struct {
+	// Window is the rolling window to evaluate the health.
+	Window time.Duration
+	// Buckets is the number of the buckets in the window, the window rolls by a bucket.
+	Buckets int
+	// MinRequests is the minimum requests in the window to evaluate the objectives,
+	// an operation with fewer requests is considered healthy.
+	MinRequests int64
+	// MinSuccessRate is the objective of the success rate, a request fails with an error or a 5xx status.
+	MinSuccessRate float64
+	// MaxLatencyP99 is the objective of the p99 latency, zero means no latency objective.
+	MaxLatencyP99 time.Duration
+	// LatencySamples is the maximum latency samples kept by each bucket, the samples are chosen randomly.
+	LatencySamples int
+}
+
+// OperationHealth is the health of an operation in the window.
+type OperationHealth struct {
+	Operation     string  `json:"operation"`
+	Requests      int64   `json:"requests"`
+	Failures      int64   `json:"failures"`
+	SuccessRate   float64 `json:"success_rate"`
+	LatencyP50Ms  float64 `json:"latency_p50_ms"`
+	LatencyP90Ms  float64 `json:"latency_p90_ms"`
+	LatencyP99Ms  float64 `json:"latency_p99_ms"`
+	Healthy       bool    `json:"healthy"`
+	WindowSeconds float64 `json:"window_seconds"`
+	LastFailure   string  `json:"last_failure,omitempty"`
+}
+
+// HealthReport is the health of all the tracked operations.
+type HealthReport struct {
+	Healthy    bool              `json:"healthy"`
+	Operations []OperationHealth `json:"operations"`
+}
+
+type healthBucket struct {
+	start     time.Time
+	requests  int64
+	failures  int64
+	seen      int64
+	latencies []time.Duration
+}
+
+type operationWindow struct {
+	buckets     []healthBucket
+	lastFailure string
+}
+
+// HealthTracker keeps the rolling windows of the success rate and latency percentiles of each operation,
+// by the full name of the operations. It serves the health report as JSON, and the health of an operation
+// can be queried to make decisions, such as breaking the circuit or failing over to another endpoint.
+type HealthTracker struct {
+	config      HealthTrackerConfig
+	bucketWidth time.Duration
+
+	lock       sync.Mutex
+	random     *rand.Rand
+	operations map[string]*operationWindow
+}
+
+// NewHealthTracker creates a HealthTracker.
+func NewHealthTracker(config HealthTrackerConfig) *HealthTracker {
+	if config.Window <= 0 {
+		config.Window = DefaultHealthWindow
+	}
+
+	if config.Buckets <= 0 {
+		config.Buckets = DefaultHealthBuckets
+	}
+
+	if config.MinRequests <= 0 {
+		config.MinRequests = DefaultHealthMinRequests
+	}
+
+	if config.MinSuccessRate <= 0 {
+		config.MinSuccessRate = DefaultHealthMinSuccessRate
+	}
+
+	if config.LatencySamples <= 0 {
+		config.LatencySamples = DefaultHealthLatencySamples
+	}
+
+	return &HealthTracker{
+		config:      config,
+		bucketWidth: config.Window / time.Duration(config.Buckets),
+		random:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		operations:  make(map[string]*operationWindow),
+	}
+}
+
+// Record records a request of the operation, it is called by the middleware of OptHealthTracker.
+func (t *HealthTracker) Record(operation string, latency time.Duration, failure error) {
+	// the start and the index of the bucket are computed from the same slot,
+	// time.Truncate counts from the zero time instead of the unix epoch
+	slot := time.Now().UnixNano() / int64(t.bucketWidth)
+	start := time.Unix(0, slot*int64(t.bucketWidth))
+	index := int(slot % int64(t.config.Buckets))
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	window, ok := t.operations[operation]
+	if !ok {
+		window = &operationWindow{buckets: make([]healthBucket, t.config.Buckets)}
+		t.operations[operation] = window
+	}
+
+	bucket := &window.buckets[index]
+	if !bucket.start.Equal(start) {
+		*bucket = healthBucket{start: start, latencies: bucket.latencies[:0]}
+	}
+
+	bucket.requests++
+	if failure != nil {
+		bucket.failures++
+		window.lastFailure = failure.Error()
+	}
+
+	// reservoir sampling keeps the memory bounded
+	bucket.seen++
+	if len(bucket.latencies) < t.config.LatencySamples {
+		bucket.latencies = append(bucket.latencies, latency)
+	} else if i := t.random.Int63n(bucket.seen); i < int64(t.config.LatencySamples) {
+		bucket.latencies[i] = latency
+	}
+}
+
+func percentileMs(sorted []time.Duration, percentile float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	index := int(float64(len(sorted))*percentile+0.5) - 1
+	if index < 0 {
+		index = 0
+	}
+
+	return float64(sorted[index]) / float64(time.Millisecond)
+}
+
+func (t *HealthTracker) evaluate(name string, window *operationWindow, now time.Time) OperationHealth {
+	health := OperationHealth{
+		Operation:     name,
+		WindowSeconds: t.config.Window.Seconds(),
+		SuccessRate:   1,
+		Healthy:       true,
+	}
+
+	var latencies []time.Duration
+	for _, bucket := range window.buckets {
+		if bucket.requests == 0 || now.Sub(bucket.start) >= t.config.Window {
+			continue
+		}
+
+		health.Requests += bucket.requests
+		health.Failures += bucket.failures
+		latencies = append(latencies, bucket.latencies...)
+	}
+
+	if health.Requests == 0 {
+		return health
+	}
+
+	if health.Failures > 0 {
+		health.LastFailure = window.lastFailure
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	health.SuccessRate = float64(health.Requests-health.Failures) / float64(health.Requests)
+	health.LatencyP50Ms = percentileMs(latencies, 0.5)
+	health.LatencyP90Ms = percentileMs(latencies, 0.9)
+	health.LatencyP99Ms = percentileMs(latencies, 0.99)
+
+	if health.Requests < t.config.MinRequests {
+		return health
+	}
+
+	health.Healthy = health.SuccessRate >= t.config.MinSuccessRate
+	if t.config.MaxLatencyP99 > 0 && health.LatencyP99Ms > float64(t.config.MaxLatencyP99)/float64(time.Millisecond) {
+		health.Healthy = false
+	}
+
+	return health
+}
+
+// Health returns the health of the operation in the window, false means the operation is not tracked.
+func (t *HealthTracker) Health(operation string) (OperationHealth, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	window, ok := t.operations[operation]
+	if !ok {
+		return OperationHealth{}, false
+	}
+
+	return t.evaluate(operation, window, time.Now()), true
+}
+
+// Healthy returns whether the operation meets the objectives, an untracked operation is considered healthy.
+func (t *HealthTracker) Healthy(operation string) bool {
+	health, ok := t.Health(operation)
+	return !ok || health.Healthy
+}
+
+// Snapshot returns the health of all the tracked operations, sorted by the operation names.
+func (t *HealthTracker) Snapshot() HealthReport {
+	now := time.Now()
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	report := HealthReport{
+		Healthy:    true,
+		Operations: make([]OperationHealth, 0, len(t.operations)),
+	}
+
+	for name, window := range t.operations {
+		health := t.evaluate(name, window, now)
+		report.Healthy = report.Healthy && health.Healthy
+		report.Operations = append(report.Operations, health)
+	}
+
+	sort.Slice(report.Operations, func(i, j int) bool {
+		return report.Operations[i].Operation < report.Operations[j].Operation
+	})
+
+	return report
+}
+
+// ServeHTTP renders the snapshot as JSON, the status is 503 when any operation is unhealthy.
+func (t *HealthTracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := t.Snapshot()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !report.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+func (t *HealthTracker) middleware(next define.RoundTrip) define.RoundTrip {
+	return func(request *http.Request) (*http.Response, error) {
+		metadata, _ := define.OperationMetadataFromContext(request.Context())
+
+		start := time.Now()
+		response, err := next(request)
+
+		failure := err
+		if failure == nil && response.StatusCode >= http.StatusInternalServerError {
+			failure = define.ErrorWrapf(define.ErrBkApiRequest, "status %d", response.StatusCode)
+		}
+
+		t.Record(metadata.FullName, time.Since(start), failure)
+
+		return response, err
+	}
+}
+
+// OptHealthTracker tracks the health of the operations by the tracker, each attempt of the retries is tracked.
+func OptHealthTracker(tracker *HealthTracker) define.BkApiOption {
+	return internal.NewMiddlewareOption(tracker.middleware)
+}
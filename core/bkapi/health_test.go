@@ -0,0 +1,159 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+var _ = Describe("HealthTracker", func() {
+	It("should track the operations by a middleware", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/fail" {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		tracker := bkapi.NewHealthTracker(bkapi.HealthTrackerConfig{MinRequests: 1})
+		client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+			Endpoint: server.URL,
+			Backend:  define.BackendNative,
+		}, bkapi.OptHealthTracker(tracker))
+		Expect(err).To(BeNil())
+		defer client.Close()
+
+		for _, name := range []string{"ok", "fail"} {
+			_, err = client.NewOperation(bkapi.OperationConfig{Name: name, Method: "GET", Path: "/" + name}).Request()
+			Expect(err).To(BeNil())
+		}
+
+		health, ok := tracker.Health("testing.api.ok")
+		Expect(ok).To(BeTrue())
+		Expect(health.Requests).To(Equal(int64(1)))
+		Expect(health.SuccessRate).To(Equal(1.0))
+		Expect(health.Healthy).To(BeTrue())
+
+		health, ok = tracker.Health("testing.api.fail")
+		Expect(ok).To(BeTrue())
+		Expect(health.Failures).To(Equal(int64(1)))
+		Expect(health.SuccessRate).To(Equal(0.0))
+		Expect(health.Healthy).To(BeFalse())
+		Expect(tracker.Healthy("testing.api.fail")).To(BeFalse())
+		Expect(tracker.Healthy("testing.api.unknown")).To(BeTrue())
+	})
+
+	It("should compute the success rate and latency percentiles", func() {
+		tracker := bkapi.NewHealthTracker(bkapi.HealthTrackerConfig{
+			MinSuccessRate: 0.9,
+			MaxLatencyP99:  150 * time.Millisecond,
+		})
+
+		for i := 1; i <= 100; i++ {
+			var failure error
+			if i%20 == 0 {
+				failure = errors.New("failed")
+			}
+
+			tracker.Record("demo", time.Duration(i)*time.Millisecond, failure)
+		}
+
+		health, ok := tracker.Health("demo")
+		Expect(ok).To(BeTrue())
+		Expect(health.Requests).To(Equal(int64(100)))
+		Expect(health.Failures).To(Equal(int64(5)))
+		Expect(health.SuccessRate).To(Equal(0.95))
+		Expect(health.LatencyP50Ms).To(Equal(50.0))
+		Expect(health.LatencyP90Ms).To(Equal(90.0))
+		Expect(health.LatencyP99Ms).To(Equal(99.0))
+		Expect(health.LastFailure).To(Equal("failed"))
+		Expect(health.Healthy).To(BeTrue())
+
+		tracker.Record("demo", 200*time.Millisecond, nil)
+		tracker.Record("demo", 200*time.Millisecond, nil)
+		Expect(tracker.Healthy("demo")).To(BeFalse())
+	})
+
+	It("should not evaluate the objectives with few requests", func() {
+		tracker := bkapi.NewHealthTracker(bkapi.HealthTrackerConfig{})
+		tracker.Record("demo", time.Millisecond, errors.New("failed"))
+
+		Expect(tracker.Healthy("demo")).To(BeTrue())
+	})
+
+	It("should forget the requests out of the window", func() {
+		tracker := bkapi.NewHealthTracker(bkapi.HealthTrackerConfig{
+			Window:      100 * time.Millisecond,
+			Buckets:     2,
+			MinRequests: 1,
+		})
+		tracker.Record("demo", time.Millisecond, errors.New("failed"))
+		Expect(tracker.Healthy("demo")).To(BeFalse())
+
+		Eventually(func() int64 {
+			health, _ := tracker.Health("demo")
+			return health.Requests
+		}).WithTimeout(time.Second).Should(BeZero())
+		Expect(tracker.Healthy("demo")).To(BeTrue())
+	})
+
+	It("should keep the requests when the bucket width does not divide the time since the zero time", func() {
+		// the width is 70ms, the time from the zero time to the unix epoch is not a multiple of it
+		tracker := bkapi.NewHealthTracker(bkapi.HealthTrackerConfig{
+			Window:  490 * time.Millisecond,
+			Buckets: 7,
+		})
+
+		recorded := int64(0)
+		for started := time.Now(); time.Since(started) < 200*time.Millisecond; recorded++ {
+			tracker.Record("demo", time.Millisecond, nil)
+			time.Sleep(time.Millisecond)
+		}
+
+		health, _ := tracker.Health("demo")
+		Expect(health.Requests).To(Equal(recorded))
+	})
+
+	It("should render the snapshot as JSON", func() {
+		tracker := bkapi.NewHealthTracker(bkapi.HealthTrackerConfig{MinRequests: 1})
+		tracker.Record("b", time.Millisecond, nil)
+		tracker.Record("a", time.Millisecond, errors.New("failed"))
+
+		recorder := httptest.NewRecorder()
+		tracker.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+
+		var report bkapi.HealthReport
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &report)).To(Succeed())
+		Expect(report.Healthy).To(BeFalse())
+		Expect(report.Operations).To(HaveLen(2))
+		Expect(report.Operations[0].Operation).To(Equal("a"))
+		Expect(report.Operations[0].Healthy).To(BeFalse())
+		Expect(report.Operations[1].Operation).To(Equal("b"))
+		Expect(report.Operations[1].Healthy).To(BeTrue())
+	})
+})
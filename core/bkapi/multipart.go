@@ -12,8 +12,6 @@
 package bkapi
 
 import (
-	"gopkg.in/h2non/gentleman.v2/plugins/multipart"
-
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
 )
@@ -32,12 +30,15 @@ func NewMultipartFormFieldsBodyProvider() *MultipartFormFieldsBodyProvider {
 				return define.ErrorWrapf(define.ErrTypeNotMatch, "expected %T, but got %T", values, v)
 			}
 
-			fields := make(map[string]multipart.Values, len(values))
-			for k, v := range values {
-				fields[k] = multipart.Values(v)
+			body, contentType, err := internal.NewMultipartBody(values)
+			if err != nil {
+				return define.ErrorWrapf(err, "failed to encode multipart form")
 			}
 
-			operation.Apply(internal.NewPluginOption(multipart.Fields(fields)))
+			operation.
+				SetContentType(contentType).
+				SetContentLength(int64(body.Len())).
+				SetBodyReader(body)
 
 			return nil
 		}),
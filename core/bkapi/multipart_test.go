@@ -12,11 +12,15 @@
 package bkapi_test
 
 import (
+	"io"
+	"io/ioutil"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal/mock"
 )
 
@@ -33,7 +37,18 @@ var _ = Describe("Multipart", func() {
 
 	It("should provide multopart form", func() {
 		operation := mock.NewMockOperation(ctrl)
-		operation.EXPECT().Apply(gomock.Any()).Return(operation)
+		operation.EXPECT().SetContentType(gomock.Any()).DoAndReturn(func(contentType string) define.Operation {
+			Expect(contentType).To(HavePrefix("multipart/form-data; boundary="))
+			return operation
+		})
+		operation.EXPECT().SetContentLength(gomock.Any()).Return(operation)
+		operation.EXPECT().SetBodyReader(gomock.Any()).DoAndReturn(func(body io.Reader) define.Operation {
+			content, err := ioutil.ReadAll(body)
+			Expect(err).To(BeNil())
+			Expect(string(content)).To(ContainSubstring(`name="hello"`))
+			Expect(string(content)).To(ContainSubstring("world"))
+			return operation
+		})
 
 		provider := bkapi.MultipartFormBodyProvider()
 		Expect(provider.ProvideBody(operation, map[string][]string{
@@ -12,6 +12,8 @@
 package bkapi
 
 import (
+	"time"
+
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
 )
 
@@ -23,6 +25,14 @@ type OperationConfig struct {
 	Method string
 	// Path is the HTTP path of the operation.
 	Path string
+	// Timeout is the default timeout of the operation, zero means no default timeout.
+	// It takes precedence over the client timeout, and can be overridden by the operation options.
+	Timeout time.Duration
+	// Idempotent marks the operation is safe to be retried.
+	Idempotent bool
+	// RetryPolicy is the retry policy of the operation, nil means no retry.
+	// Only the idempotent operations or the operations with an idempotency key will be retried.
+	RetryPolicy *define.RetryPolicy
 }
 
 // ProvideConfig clone and returns a new OperationConfig.
@@ -45,6 +55,21 @@ func (c *OperationConfig) GetPath() string {
 	return c.Path
 }
 
+// GetTimeout returns the default timeout of the operation.
+func (c *OperationConfig) GetTimeout() time.Duration {
+	return c.Timeout
+}
+
+// IsIdempotent returns whether the operation is idempotent.
+func (c *OperationConfig) IsIdempotent() bool {
+	return c.Idempotent
+}
+
+// GetRetryPolicy returns the retry policy of the operation.
+func (c *OperationConfig) GetRetryPolicy() *define.RetryPolicy {
+	return c.RetryPolicy
+}
+
 // OperationOption is a wrapper for a operation option.
 type OperationOption struct {
 	fn func(operation define.Operation) error
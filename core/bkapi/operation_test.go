@@ -12,6 +12,12 @@
 package bkapi_test
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -40,6 +46,21 @@ var _ = Describe("Operation", func() {
 			Expect(providedConfig.GetPath()).To(Equal("/test"))
 			Expect(providedConfig.GetMethod()).To(Equal("GET"))
 		})
+
+		It("should provide the operation policy", func() {
+			retryPolicy := &define.RetryPolicy{MaxAttempts: 3}
+			config := bkapi.OperationConfig{
+				Timeout:     time.Second,
+				Idempotent:  true,
+				RetryPolicy: retryPolicy,
+			}
+
+			policy, ok := config.ProvideConfig().(define.OperationPolicyConfig)
+			Expect(ok).To(BeTrue())
+			Expect(policy.GetTimeout()).To(Equal(time.Second))
+			Expect(policy.IsIdempotent()).To(BeTrue())
+			Expect(policy.GetRetryPolicy()).To(Equal(retryPolicy))
+		})
 	})
 
 	Context("OperationOption", func() {
@@ -110,4 +131,70 @@ var _ = Describe("Operation", func() {
 			Expect(option.ApplyToOperation(operation)).To(Succeed())
 		})
 	})
+
+	DescribeTable("should advertise the time left on each attempt of the retries", func(backend define.Backend) {
+		var (
+			lock     sync.Mutex
+			timeouts []int
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout, _ := strconv.Atoi(r.Header.Get(define.RequestTimeoutHeader))
+
+			lock.Lock()
+			timeouts = append(timeouts, timeout)
+			attempt := len(timeouts)
+			lock.Unlock()
+
+			if attempt == 1 {
+				time.Sleep(50 * time.Millisecond)
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{Endpoint: server.URL, Backend: backend})
+		Expect(err).To(BeNil())
+		defer client.Close()
+
+		response, err := client.NewOperation(bkapi.OperationConfig{
+			Method:      "GET",
+			Path:        "/",
+			Timeout:     10 * time.Second,
+			Idempotent:  true,
+			RetryPolicy: &define.RetryPolicy{MaxAttempts: 2},
+		}).Request()
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		Expect(timeouts).To(HaveLen(2))
+		Expect(timeouts[0]).To(BeNumerically(">", 0))
+		Expect(timeouts[0]).To(BeNumerically("<=", 10000))
+		Expect(timeouts[1]).To(BeNumerically("<=", timeouts[0]-50))
+	},
+		Entry("gentleman", define.BackendGentleman),
+		Entry("native", define.BackendNative),
+	)
+
+	DescribeTable("should send the request with a nil context", func(backend define.Backend) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{Endpoint: server.URL, Backend: backend})
+		Expect(err).To(BeNil())
+		defer client.Close()
+
+		// nolint:staticcheck // a nil context is used as context.Background()
+		response, err := client.NewOperation(bkapi.OperationConfig{Method: "GET", Path: "/"}).
+			SetContext(nil).
+			Request()
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+	},
+		Entry("gentleman", define.BackendGentleman),
+		Entry("native", define.BackendNative),
+	)
 })
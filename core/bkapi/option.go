@@ -13,6 +13,7 @@ package bkapi
 
 import (
 	"net/http"
+	"net/url"
 
 	"gopkg.in/h2non/gentleman.v2/context"
 	"gopkg.in/h2non/gentleman.v2/plugin"
@@ -24,21 +25,23 @@ import (
 // OptAddRequestQueryParamList adds the query param value list to key.
 // It appends to any existing values associated with key.
 func OptAddRequestQueryParamList(key string, values []string) define.BkApiOption {
-	return internal.NewPluginOption(plugin.NewRequestPlugin(func(ctx *context.Context, h context.Handler) {
-		query := ctx.Request.URL.Query()
-
+	return queryHookOption(func(query url.Values) {
 		for _, value := range values {
 			query.Add(key, value)
 		}
-
-		ctx.Request.URL.RawQuery = query.Encode()
-		h.Next(ctx)
-	}))
+	})
 }
 
 // OptRequestCallback sets the callback function for the request.
 func OptRequestCallback(fn func(request *http.Request) *http.Request) define.BkApiOption {
-	return internal.NewPluginOption(plugin.NewRequestPlugin(func(ctx *context.Context, h context.Handler) {
+	return internal.NewRequestHookOption(func(request *http.Request) error {
+		newRequest := fn(request)
+		if newRequest != nil && newRequest != request {
+			*request = *newRequest
+		}
+
+		return nil
+	}).WithPlugin(plugin.NewRequestPlugin(func(ctx *context.Context, h context.Handler) {
 		ctx.Request = fn(ctx.Request)
 		h.Next(ctx)
 	}))
@@ -46,7 +49,16 @@ func OptRequestCallback(fn func(request *http.Request) *http.Request) define.BkA
 
 // OptResponseCallback sets the callback function for the response.
 func OptResponseCallback(fn func(response *http.Response) *http.Response) define.BkApiOption {
-	return internal.NewPluginOption(plugin.NewResponsePlugin(func(ctx *context.Context, h context.Handler) {
+	return internal.NewMiddlewareOption(func(next define.RoundTrip) define.RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+			response, err := next(request)
+			if err != nil {
+				return response, err
+			}
+
+			return fn(response), nil
+		}
+	}).WithPlugin(plugin.NewResponsePlugin(func(ctx *context.Context, h context.Handler) {
 		ctx.Response = fn(ctx.Response)
 		h.Next(ctx)
 	}))
@@ -54,8 +66,52 @@ func OptResponseCallback(fn func(response *http.Response) *http.Response) define
 
 // OptErrorCallback sets the callback function for the error.
 func OptErrorCallback(fn func(err error) error) define.BkApiOption {
-	return internal.NewPluginOption(plugin.NewErrorPlugin(func(ctx *context.Context, h context.Handler) {
+	return internal.NewMiddlewareOption(func(next define.RoundTrip) define.RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+			response, err := next(request)
+			if err != nil {
+				return response, fn(err)
+			}
+
+			return response, nil
+		}
+	}).WithPlugin(plugin.NewErrorPlugin(func(ctx *context.Context, h context.Handler) {
 		ctx.Error = fn(ctx.Error)
 		h.Next(ctx)
 	}))
 }
+
+// OptMiddleware wraps the requests by the middlewares, it works for all backends.
+// Applied to a client, the middlewares wrap all the operations of the client,
+// and the operation metadata can be got by define.OperationMetadataFromContext.
+func OptMiddleware(mws ...define.Middleware) define.BkApiOption {
+	return internal.NewMiddlewareOption(mws...)
+}
+
+// OptIdempotencyKey sets the idempotency key of the operation, which is sent by the Idempotency-Key header.
+// A random key will be generated for each operation when the key is empty,
+// and it keeps stable across the retries of the same operation.
+// The key is also exposed by the returned error (define.IdempotencyKeyError) and the log fields.
+func OptIdempotencyKey(key string) define.OperationOption {
+	return NewOperationOption(func(op define.Operation) error {
+		operation, ok := op.(internal.PolicyOperation)
+		if !ok {
+			return define.ErrorWrapf(
+				define.ErrTypeNotMatch, "expected type %T, got %T", operation, op,
+			)
+		}
+
+		idempotencyKey := key
+		if idempotencyKey == "" {
+			var err error
+			idempotencyKey, err = internal.NewIdempotencyKey()
+			if err != nil {
+				return err
+			}
+		}
+
+		operation.SetIdempotencyKey(idempotencyKey)
+
+		return nil
+	})
+}
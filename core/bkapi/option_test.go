@@ -12,6 +12,7 @@
 package bkapi_test
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -130,4 +131,32 @@ var _ = Describe("Option", func() {
 			applyOptions(opt)
 		})
 	})
+
+	Context("OptIdempotencyKey", func() {
+		It("should send the given key", func() {
+			response := applyOptions(bkapi.OptIdempotencyKey("testing"))
+
+			Expect(response.Request.Header.Get(define.IdempotencyKeyHeader)).To(Equal("testing"))
+			Expect(operation.IdempotencyKey()).To(Equal("testing"))
+		})
+
+		It("should generate a key when it is empty", func() {
+			response := applyOptions(bkapi.OptIdempotencyKey(""))
+
+			key := response.Request.Header.Get(define.IdempotencyKeyHeader)
+			Expect(key).To(HaveLen(36))
+			Expect(operation.IdempotencyKey()).To(Equal(key))
+		})
+
+		It("should expose the key by the error", func() {
+			requestError = fmt.Errorf("testing")
+
+			_, err := operation.Apply(bkapi.OptIdempotencyKey("testing")).Request()
+			Expect(err).NotTo(BeNil())
+
+			var keyErr define.IdempotencyKeyError
+			Expect(errors.As(err, &keyErr)).To(BeTrue())
+			Expect(keyErr.IdempotencyKey()).To(Equal("testing"))
+		})
+	})
 })
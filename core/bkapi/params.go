@@ -0,0 +1,326 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+const (
+	queryParamTag  = "query"
+	headerParamTag = "header"
+	pathParamTag   = "path"
+	timeFormatTag  = "time_format"
+)
+
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	durationType     = reflect.TypeOf(time.Duration(0))
+	paramEncoderType = reflect.TypeOf((*ParamEncoder)(nil)).Elem()
+	textMarshalType  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// ParamEncoder can be implemented by the field types to customize how the values are encoded as parameters.
+type ParamEncoder interface {
+	// EncodeParam returns the encoded values, an empty result means the parameter should be omitted.
+	EncodeParam() ([]string, error)
+}
+
+// RequestParams holds the parameters parsed from the struct tags.
+type RequestParams struct {
+	Query  map[string][]string
+	Header map[string][]string
+	Path   map[string]string
+}
+
+type paramTag struct {
+	name      string
+	omitEmpty bool
+}
+
+func parseParamTag(field reflect.StructField, key string) (paramTag, bool) {
+	value, ok := field.Tag.Lookup(key)
+	if !ok || value == "-" {
+		return paramTag{}, false
+	}
+
+	parts := strings.Split(value, ",")
+	tag := paramTag{name: parts[0]}
+	if tag.name == "" {
+		tag.name = field.Name
+	}
+
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			tag.omitEmpty = true
+		}
+	}
+
+	return tag, true
+}
+
+func formatTime(t time.Time, layout string) string {
+	switch layout {
+	case "":
+		return t.Format(time.RFC3339)
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "unixmilli":
+		return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+	default:
+		return t.Format(layout)
+	}
+}
+
+func encodeScalarParam(value reflect.Value, layout string) (string, error) {
+	switch {
+	case value.Type() == timeType:
+		return formatTime(value.Interface().(time.Time), layout), nil
+	case value.Type() == durationType:
+		return value.Interface().(time.Duration).String(), nil
+	case value.Type().Implements(textMarshalType):
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+	default:
+		return "", define.ErrorWrapf(define.ErrTypeNotMatch, "unsupported param type %s", value.Type())
+	}
+}
+
+// encodeParam encodes the value to the parameter values, nil means the parameter should be omitted.
+func encodeParam(value reflect.Value, layout string) ([]string, error) {
+	if value.Type().Implements(paramEncoderType) {
+		if (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil() {
+			return nil, nil
+		}
+
+		return value.Interface().(ParamEncoder).EncodeParam()
+	}
+
+	if value.CanAddr() && reflect.PtrTo(value.Type()).Implements(paramEncoderType) {
+		return value.Addr().Interface().(ParamEncoder).EncodeParam()
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		return encodeParam(value.Elem(), layout)
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil, nil
+		}
+
+		values := make([]string, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			elem, err := encodeParam(value.Index(i), layout)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, elem...)
+		}
+
+		return values, nil
+	default:
+		encoded, err := encodeScalarParam(value, layout)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{encoded}, nil
+	}
+}
+
+func (p *RequestParams) parseField(field reflect.StructField, value reflect.Value) error {
+	for _, key := range []string{queryParamTag, headerParamTag, pathParamTag} {
+		tag, ok := parseParamTag(field, key)
+		if !ok {
+			continue
+		}
+
+		if tag.omitEmpty && value.IsZero() {
+			continue
+		}
+
+		values, err := encodeParam(value, field.Tag.Get(timeFormatTag))
+		if err != nil {
+			return define.ErrorWrapf(err, "failed to encode field %s", field.Name)
+		}
+
+		if values == nil {
+			continue
+		}
+
+		switch key {
+		case queryParamTag:
+			p.Query[tag.name] = append(p.Query[tag.name], values...)
+		case headerParamTag:
+			p.Header[tag.name] = append(p.Header[tag.name], values...)
+		case pathParamTag:
+			p.Path[tag.name] = strings.Join(values, ",")
+		}
+	}
+
+	return nil
+}
+
+func (p *RequestParams) parseStruct(value reflect.Value) error {
+	typ := value.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldValue := value.Field(i)
+
+		_, tagged := field.Tag.Lookup(queryParamTag)
+		if !tagged {
+			_, tagged = field.Tag.Lookup(headerParamTag)
+		}
+		if !tagged {
+			_, tagged = field.Tag.Lookup(pathParamTag)
+		}
+
+		// the untagged embedded structs are flattened
+		if field.Anonymous && !tagged {
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					continue
+				}
+				fieldValue = fieldValue.Elem()
+			}
+
+			if fieldValue.Kind() == reflect.Struct {
+				err := p.parseStruct(fieldValue)
+				if err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if !tagged || field.PkgPath != "" {
+			continue
+		}
+
+		err := p.parseField(field, fieldValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewRequestParams parses the query, header and path parameters from the struct tags of v.
+// The supported tags are `query:"name"`, `header:"name"` and `path:"name"`, with an optional omitempty flag.
+// Nil pointers are always omitted, slices are encoded as repeated values (joined by comma for path),
+// and the time layout can be specified by `time_format:"layout"`, including "unix" and "unixmilli".
+func NewRequestParams(v interface{}) (*RequestParams, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, define.ErrorWrapf(define.ErrTypeNotMatch, "expected a struct, but got nil %T", v)
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, define.ErrorWrapf(define.ErrTypeNotMatch, "expected a struct, but got %T", v)
+	}
+
+	params := &RequestParams{
+		Query:  make(map[string][]string),
+		Header: make(map[string][]string),
+		Path:   make(map[string]string),
+	}
+
+	err := params.parseStruct(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+func (p *RequestParams) modifyRequest(request *http.Request) error {
+	if len(p.Query) > 0 {
+		query := request.URL.Query()
+		for key, values := range p.Query {
+			query[key] = append([]string(nil), values...)
+		}
+		request.URL.RawQuery = query.Encode()
+	}
+
+	for key, values := range p.Header {
+		request.Header.Del(key)
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+
+	return nil
+}
+
+// ApplyToOperation will apply the parameters to the operation.
+func (p *RequestParams) ApplyToOperation(op define.Operation) error {
+	if len(p.Path) > 0 {
+		op.SetPathParams(p.Path)
+	}
+
+	if len(p.Query) > 0 || len(p.Header) > 0 {
+		op.Apply(internal.NewRequestHookOption(p.modifyRequest))
+	}
+
+	return nil
+}
+
+// String renders the parameters.
+func (p *RequestParams) String() string {
+	return fmt.Sprintf("query: %v, header: %v, path: %v", p.Query, p.Header, p.Path)
+}
+
+// OptSetRequestParams sets the query, header and path parameters of the operation by the struct tags of v,
+// see NewRequestParams for the supported tags.
+func OptSetRequestParams(v interface{}) define.OperationOption {
+	return NewOperationOption(func(op define.Operation) error {
+		params, err := NewRequestParams(v)
+		if err != nil {
+			return err
+		}
+
+		return params.ApplyToOperation(op)
+	})
+}
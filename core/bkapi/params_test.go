@@ -0,0 +1,154 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi_test
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	gentleman "gopkg.in/h2non/gentleman.v2"
+	"gopkg.in/h2non/gentleman.v2/plugins/transport"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal/mock"
+)
+
+type testingLabels []string
+
+func (l testingLabels) EncodeParam() ([]string, error) {
+	return []string{strings.Join(l, "|")}, nil
+}
+
+type testingPagination struct {
+	Limit  int `query:"limit,omitempty"`
+	Offset int `query:"offset,omitempty"`
+}
+
+type testingParams struct {
+	testingPagination
+
+	Name      string        `path:"name"`
+	Ids       []int         `query:"id"`
+	Keyword   *string       `query:"keyword"`
+	Enabled   bool          `query:"enabled"`
+	Since     time.Time     `query:"since" time_format:"2006-01-02"`
+	Until     time.Time     `query:"until" time_format:"unix"`
+	Timeout   time.Duration `query:"timeout,omitempty"`
+	Labels    testingLabels `query:"labels"`
+	RequestId string        `header:"X-Request-Id,omitempty"`
+	Ignored   string        `query:"-"`
+	Untagged  string
+}
+
+var _ = Describe("Params", func() {
+	Context("NewRequestParams", func() {
+		It("should parse the params by tags", func() {
+			keyword := "testing"
+			params, err := bkapi.NewRequestParams(&testingParams{
+				testingPagination: testingPagination{Limit: 10},
+				Name:              "a/b",
+				Ids:               []int{1, 2},
+				Keyword:           &keyword,
+				Since:             time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				Until:             time.Unix(1700000000, 0),
+				Labels:            testingLabels{"a", "b"},
+				RequestId:         "request-id",
+				Ignored:           "ignored",
+				Untagged:          "untagged",
+			})
+			Expect(err).To(BeNil())
+
+			Expect(params.Path).To(Equal(map[string]string{"name": "a/b"}))
+			Expect(params.Header).To(Equal(map[string][]string{"X-Request-Id": {"request-id"}}))
+			Expect(params.Query).To(Equal(map[string][]string{
+				"limit":   {"10"},
+				"id":      {"1", "2"},
+				"keyword": {"testing"},
+				"enabled": {"false"},
+				"since":   {"2024-01-02"},
+				"until":   {"1700000000"},
+				"labels":  {"a|b"},
+			}))
+		})
+
+		It("should omit the nil pointers and empty values", func() {
+			params, err := bkapi.NewRequestParams(testingParams{})
+			Expect(err).To(BeNil())
+
+			Expect(params.Query).NotTo(HaveKey("keyword"))
+			Expect(params.Query).NotTo(HaveKey("limit"))
+			Expect(params.Query).NotTo(HaveKey("id"))
+			Expect(params.Header).To(BeEmpty())
+		})
+
+		It("should fail when the value is not a struct", func() {
+			_, err := bkapi.NewRequestParams(map[string]string{})
+			Expect(errors.Cause(err)).To(Equal(define.ErrTypeNotMatch))
+		})
+
+		It("should fail when the field type is not supported", func() {
+			_, err := bkapi.NewRequestParams(struct {
+				Value map[string]string `query:"value"`
+			}{Value: map[string]string{}})
+			Expect(errors.Cause(err)).To(Equal(define.ErrTypeNotMatch))
+		})
+	})
+
+	Context("OptSetRequestParams", func() {
+		var (
+			ctrl         *gomock.Controller
+			roundTripper *mock.MockRoundTripper
+			bkapiClient  *mock.MockBkApiClient
+		)
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			roundTripper = mock.NewMockRoundTripper(ctrl)
+			bkapiClient = mock.NewMockBkApiClient(ctrl)
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		It("should apply the params to the operation", func() {
+			roundTripper.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Request:    req,
+				}, nil
+			})
+
+			request := gentleman.NewRequest().URL("http://example.com/pets/{name}/?id=0")
+			request.Use(transport.Set(roundTripper))
+			operation := internal.NewOperation("testing", bkapiClient, request)
+
+			response, err := operation.Apply(bkapi.OptSetRequestParams(&testingParams{
+				Name:      "dog",
+				Ids:       []int{1, 2},
+				RequestId: "request-id",
+			})).Request()
+			Expect(err).To(BeNil())
+
+			Expect(response.Request.URL.Path).To(Equal("/pets/dog/"))
+			Expect(response.Request.URL.Query()["id"]).To(Equal([]string{"1", "2"}))
+			Expect(response.Request.Header.Get("X-Request-Id")).To(Equal("request-id"))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+// ConnectionPoolConfig is the connection pool settings of the transport, zero values keep the defaults.
+type ConnectionPoolConfig struct {
+	// MaxIdleConns controls the maximum number of idle connections across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost controls the maximum idle connections to keep per host,
+	// the default value of net/http is 2, which is too small for the high QPS services.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the total number of connections per host, including the active ones.
+	MaxConnsPerHost int
+	// IdleConnTimeout is the maximum amount of time an idle connection will remain idle before closing itself.
+	IdleConnTimeout time.Duration
+}
+
+// OptConnectionPool defines the connection pool settings of the client transport.
+func OptConnectionPool(config ConnectionPoolConfig) define.BkApiClientOption {
+	return transportHookOption(func(transport *http.Transport) {
+		if config.MaxIdleConns > 0 {
+			transport.MaxIdleConns = config.MaxIdleConns
+		}
+
+		if config.MaxIdleConnsPerHost > 0 {
+			transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+		}
+
+		if config.MaxConnsPerHost > 0 {
+			transport.MaxConnsPerHost = config.MaxConnsPerHost
+		}
+
+		if config.IdleConnTimeout > 0 {
+			transport.IdleConnTimeout = config.IdleConnTimeout
+		}
+	})
+}
+
+// OptKeepAlive enables or disables the HTTP keep-alives of the client transport,
+// a connection will be used for a single request only when the keep-alives are disabled.
+func OptKeepAlive(enabled bool) define.BkApiClientOption {
+	return transportHookOption(func(transport *http.Transport) {
+		transport.DisableKeepAlives = !enabled
+	})
+}
+
+// OptHTTP2 enables or disables HTTP/2 of the client transport, HTTP/2 works on the TLS connections only.
+// When enabled, HTTP/2 is attempted even if a custom dialer or TLS config is provided.
+func OptHTTP2(enabled bool) define.BkApiClientOption {
+	return transportHookOption(func(transport *http.Transport) {
+		transport.ForceAttemptHTTP2 = enabled
+		if enabled {
+			transport.TLSNextProto = nil
+			return
+		}
+
+		// a non-nil empty map disables HTTP/2
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	})
+}
+
+// GetConnectionStats returns the connection reuse statistics of the client,
+// false is returned when the client does not support it.
+func GetConnectionStats(client define.BkApiClient) (define.ConnectionStats, bool) {
+	provider, ok := client.(define.ConnectionStatsProvider)
+	if !ok {
+		return define.ConnectionStats{}, false
+	}
+
+	return provider.ConnectionStats(), true
+}
@@ -0,0 +1,184 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+var _ = Describe("Pool", func() {
+	Context("Option", func() {
+		var (
+			transport *http.Transport
+			client    *internal.HttpBkApiClient
+		)
+
+		BeforeEach(func() {
+			var err error
+
+			transport = &http.Transport{}
+			client, err = internal.NewHttpBkApiClient(
+				"testing", &http.Client{Transport: transport},
+				bkapi.ClientConfig{Endpoint: "http://example.com"}.ProvideConfig("testing"),
+			)
+			Expect(err).To(BeNil())
+		})
+
+		It("should set the connection pool", func() {
+			Expect(client.Apply(bkapi.OptConnectionPool(bkapi.ConnectionPoolConfig{
+				MaxIdleConns:        100,
+				MaxIdleConnsPerHost: 10,
+				IdleConnTimeout:     time.Minute,
+			}))).To(Succeed())
+
+			Expect(transport.MaxIdleConns).To(Equal(100))
+			Expect(transport.MaxIdleConnsPerHost).To(Equal(10))
+			Expect(transport.MaxConnsPerHost).To(Equal(0))
+			Expect(transport.IdleConnTimeout).To(Equal(time.Minute))
+		})
+
+		It("should disable the keep-alives", func() {
+			Expect(client.Apply(bkapi.OptKeepAlive(false))).To(Succeed())
+			Expect(transport.DisableKeepAlives).To(BeTrue())
+		})
+
+		It("should enable or disable HTTP/2", func() {
+			Expect(client.Apply(bkapi.OptHTTP2(false))).To(Succeed())
+			Expect(transport.ForceAttemptHTTP2).To(BeFalse())
+			Expect(transport.TLSNextProto).To(BeEmpty())
+			Expect(transport.TLSNextProto).NotTo(BeNil())
+
+			Expect(client.Apply(bkapi.OptHTTP2(true))).To(Succeed())
+			Expect(transport.ForceAttemptHTTP2).To(BeTrue())
+			Expect(transport.TLSNextProto).To(BeNil())
+		})
+	})
+
+	Context("Client", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		request := func(client define.BkApiClient) {
+			_, err := client.NewOperation(bkapi.OperationConfig{Method: "GET", Path: "/"}).Request()
+			Expect(err).To(BeNil())
+		}
+
+		DescribeTable("should share the connections between the clients", func(backend define.Backend) {
+			config := bkapi.ClientConfig{
+				Endpoint:       server.URL,
+				Backend:        backend,
+				ShareTransport: true,
+			}
+
+			first, err := bkapi.NewBkApiClient("first", config)
+			Expect(err).To(BeNil())
+			defer first.Close()
+
+			second, err := bkapi.NewBkApiClient("second", config)
+			Expect(err).To(BeNil())
+			defer second.Close()
+
+			request(first)
+			request(second)
+
+			stats, ok := bkapi.GetConnectionStats(second)
+			Expect(ok).To(BeTrue())
+			Expect(stats.Requests).To(Equal(int64(2)))
+			Expect(stats.NewConns).To(Equal(int64(1)))
+			Expect(stats.ReusedConns).To(Equal(int64(1)))
+		},
+			Entry("gentleman", define.BackendGentleman),
+			Entry("native", define.BackendNative),
+		)
+
+		DescribeTable("should reuse the connections of the default transport", func(backend define.Backend) {
+			config := bkapi.ClientConfig{
+				Endpoint: server.URL,
+				Backend:  backend,
+			}
+
+			first, err := bkapi.NewBkApiClient("first", config)
+			Expect(err).To(BeNil())
+			defer first.Close()
+
+			second, err := bkapi.NewBkApiClient("second", config)
+			Expect(err).To(BeNil())
+			defer second.Close()
+
+			request(first)
+			request(second)
+
+			stats, ok := bkapi.GetConnectionStats(second)
+			Expect(ok).To(BeTrue())
+			Expect(stats.Requests).To(Equal(int64(1)))
+			Expect(stats.ReusedConns).To(Equal(int64(1)))
+		},
+			Entry("gentleman", define.BackendGentleman),
+			Entry("native", define.BackendNative),
+		)
+
+		DescribeTable("should configure a copy of the default transport", func(backend define.Backend) {
+			client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+				Endpoint: server.URL,
+				Backend:  backend,
+			}, bkapi.OptKeepAlive(false))
+			Expect(err).To(BeNil())
+			defer client.Close()
+
+			request(client)
+			request(client)
+
+			stats, ok := bkapi.GetConnectionStats(client)
+			Expect(ok).To(BeTrue())
+			Expect(stats.NewConns).To(Equal(int64(2)))
+			Expect(http.DefaultTransport.(*http.Transport).DisableKeepAlives).To(BeFalse())
+		},
+			Entry("gentleman", define.BackendGentleman),
+			Entry("native", define.BackendNative),
+		)
+
+		It("should not modify the default transport by the transport options", func() {
+			defaultTransport := http.DefaultTransport.(*http.Transport)
+			tlsConfig := defaultTransport.TLSClientConfig
+
+			client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+				Endpoint: server.URL,
+			}, bkapi.OptTLS(&tls.Config{InsecureSkipVerify: true}))
+			Expect(err).To(BeNil())
+
+			request(client)
+			Expect(client.Close()).To(Succeed())
+			Expect(client.Close()).To(Succeed())
+
+			Expect(defaultTransport.TLSClientConfig).To(BeIdenticalTo(tlsConfig))
+		})
+	})
+})
@@ -0,0 +1,136 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi
+
+import (
+	"crypto/hmac"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+// DefaultSignatureMaxSkew is the default maximum difference between the signing time and the verifying time.
+const DefaultSignatureMaxSkew = 5 * time.Minute
+
+// SignatureVerifier verifies the requests signed by ClientConfig.SignRequest, it is useful for local test servers.
+//
+// The signature is the base64 encoded HMAC-SHA256 of the lines below, keyed by the app secret:
+//
+//	METHOD
+//	escaped path
+//	query, sorted by key
+//	unix timestamp, the X-Bkapi-Timestamp header
+//	random nonce, the X-Bkapi-Nonce header
+//	hex encoded SHA256 of the body
+//
+// and it is sent by the X-Bkapi-Signature header.
+type SignatureVerifier struct {
+	key     []byte
+	maxSkew time.Duration
+
+	lock   sync.Mutex
+	nonces map[string]time.Time
+}
+
+// NewSignatureVerifier creates a SignatureVerifier, zero maxSkew means DefaultSignatureMaxSkew.
+func NewSignatureVerifier(appSecret string, maxSkew time.Duration) *SignatureVerifier {
+	if maxSkew <= 0 {
+		maxSkew = DefaultSignatureMaxSkew
+	}
+
+	return &SignatureVerifier{
+		key:     []byte(appSecret),
+		maxSkew: maxSkew,
+		nonces:  make(map[string]time.Time),
+	}
+}
+
+func (v *SignatureVerifier) checkTimestamp(value string, now time.Time) error {
+	timestamp, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return define.ErrorWrapf(define.ErrSignatureInvalid, "invalid timestamp %q", value)
+	}
+
+	skew := now.Sub(time.Unix(timestamp, 0))
+	if skew > v.maxSkew || skew < -v.maxSkew {
+		return define.ErrorWrapf(define.ErrSignatureInvalid, "timestamp %s is out of range", value)
+	}
+
+	return nil
+}
+
+// useNonce records the nonce, a nonce can be used once within the time window.
+func (v *SignatureVerifier) useNonce(nonce string, now time.Time) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	for key, expiredAt := range v.nonces {
+		if now.After(expiredAt) {
+			delete(v.nonces, key)
+		}
+	}
+
+	if _, ok := v.nonces[nonce]; ok {
+		return define.ErrorWrapf(define.ErrSignatureInvalid, "nonce %s is replayed", nonce)
+	}
+
+	// the timestamp may be ahead of now, so the nonce is kept for both sides of the window
+	v.nonces[nonce] = now.Add(2 * v.maxSkew)
+
+	return nil
+}
+
+// Verify checks the signature, timestamp and nonce of the request, the request body can still be read afterwards.
+func (v *SignatureVerifier) Verify(request *http.Request) error {
+	timestamp := request.Header.Get(internal.SignatureTimestampHeader)
+	nonce := request.Header.Get(internal.SignatureNonceHeader)
+	signature := request.Header.Get(internal.SignatureHeader)
+	if timestamp == "" || nonce == "" || signature == "" {
+		return define.ErrorWrapf(define.ErrSignatureInvalid, "missing signature headers")
+	}
+
+	now := time.Now()
+	err := v.checkTimestamp(timestamp, now)
+	if err != nil {
+		return err
+	}
+
+	body, err := internal.ReadRequestBody(request)
+	if err != nil {
+		return define.ErrorWrapf(err, "failed to read body to verify")
+	}
+
+	expected := internal.ComputeSignature(v.key, internal.CanonicalRequest(request, timestamp, nonce, body))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return define.ErrorWrapf(define.ErrSignatureInvalid, "signature mismatch")
+	}
+
+	// only the verified nonces are recorded, so the forged requests cannot exhaust them
+	return v.useNonce(nonce, now)
+}
+
+// Middleware returns a handler which responds 401 when the request is not signed correctly.
+func (v *SignatureVerifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := v.Verify(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,193 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+var _ = Describe("Signature", func() {
+	var (
+		verifier *bkapi.SignatureVerifier
+		server   *httptest.Server
+		failures int32
+		body     string
+		auth     string
+	)
+
+	BeforeEach(func() {
+		verifier = bkapi.NewSignatureVerifier("secret", 0)
+		failures = 0
+
+		server = httptest.NewServer(verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			content, _ := ioutil.ReadAll(r.Body)
+			body = string(content)
+			auth = r.Header.Get("X-Bkapi-Authorization")
+
+			if atomic.AddInt32(&failures, -1) >= 0 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		})))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newClient := func(backend define.Backend, secret string) define.BkApiClient {
+		client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+			Endpoint:    server.URL + "/prod",
+			Backend:     backend,
+			AppCode:     "app",
+			AppSecret:   secret,
+			SignRequest: true,
+		})
+		Expect(err).To(BeNil())
+
+		return client
+	}
+
+	DescribeTable("should sign the requests instead of sending the secret", func(backend define.Backend) {
+		client := newClient(backend, "secret")
+		defer client.Close()
+
+		_, err := client.NewOperation(
+			bkapi.OperationConfig{Method: "POST", Path: "/hello/{name}"},
+			bkapi.OptSetRequestPathParams(map[string]string{"name": "world"}),
+			bkapi.OptSetRequestQueryParams(map[string]string{"b": "2", "a": "1"}),
+			bkapi.OptJsonBodyProvider(),
+			bkapi.OptSetRequestBody(map[string]string{"hello": "world"}),
+		).Request()
+		Expect(err).To(BeNil())
+
+		Expect(body).To(Equal(`{"hello":"world"}`))
+		Expect(auth).To(ContainSubstring(`"bk_app_code":"app"`))
+		Expect(auth).NotTo(ContainSubstring("secret"))
+	},
+		Entry("gentleman", define.BackendGentleman),
+		Entry("native", define.BackendNative),
+	)
+
+	DescribeTable("should sign each attempt of the retries", func(backend define.Backend) {
+		client := newClient(backend, "secret")
+		defer client.Close()
+
+		failures = 1
+		_, err := client.NewOperation(bkapi.OperationConfig{
+			Method:      "PUT",
+			Path:        "/hello",
+			Idempotent:  true,
+			RetryPolicy: &define.RetryPolicy{MaxAttempts: 2},
+		}, bkapi.OptJsonBodyProvider(), bkapi.OptSetRequestBody(map[string]string{"hello": "world"})).Request()
+		Expect(err).To(BeNil())
+		Expect(failures).To(BeNumerically("<", 0))
+	},
+		Entry("gentleman", define.BackendGentleman),
+		Entry("native", define.BackendNative),
+	)
+
+	DescribeTable("should sign the changes of the operation middlewares", func(backend define.Backend) {
+		client := newClient(backend, "secret")
+		defer client.Close()
+
+		var query string
+		response, err := client.NewOperation(
+			bkapi.OperationConfig{Method: "GET", Path: "/hello"},
+			bkapi.OptSetRequestQueryParams(map[string]string{"a": "1"}),
+			bkapi.OptMiddleware(func(next define.RoundTrip) define.RoundTrip {
+				return func(request *http.Request) (*http.Response, error) {
+					request = request.Clone(request.Context())
+					values := request.URL.Query()
+					values.Set("b", "2")
+					request.URL.RawQuery = values.Encode()
+					request.Header.Set("X-Changed", "true")
+					query = request.URL.RawQuery
+
+					return next(request)
+				}
+			}),
+		).Request()
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(query).To(Equal("a=1&b=2"))
+	},
+		Entry("gentleman", define.BackendGentleman),
+		Entry("native", define.BackendNative),
+	)
+
+	It("should reject the request signed by a wrong secret", func() {
+		client := newClient(define.BackendNative, "wrong")
+		defer client.Close()
+
+		response, err := client.NewOperation(bkapi.OperationConfig{Method: "GET", Path: "/"}).Request()
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
+	Context("SignatureVerifier", func() {
+		sign := func(request *http.Request) *http.Request {
+			var signed *http.Request
+			_, err := internal.NewSignatureMiddleware("secret")(func(r *http.Request) (*http.Response, error) {
+				signed = r
+				return nil, nil
+			})(request)
+			Expect(err).To(BeNil())
+
+			return signed
+		}
+
+		It("should reject the replayed request", func() {
+			request := sign(httptest.NewRequest("GET", "/hello?a=1", nil))
+
+			Expect(verifier.Verify(request)).To(Succeed())
+			Expect(define.ErrorCause(verifier.Verify(request))).To(Equal(define.ErrSignatureInvalid))
+		})
+
+		It("should reject the tampered request", func() {
+			request := sign(httptest.NewRequest("POST", "/hello?a=1", strings.NewReader("body")))
+			request.URL.RawQuery = "a=2"
+
+			Expect(define.ErrorCause(verifier.Verify(request))).To(Equal(define.ErrSignatureInvalid))
+		})
+
+		It("should reject the expired request", func() {
+			request := sign(httptest.NewRequest("GET", "/hello", nil))
+			request.Header.Set(
+				internal.SignatureTimestampHeader, strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10),
+			)
+
+			Expect(define.ErrorCause(verifier.Verify(request))).To(Equal(define.ErrSignatureInvalid))
+		})
+
+		It("should reject the unsigned request", func() {
+			request := httptest.NewRequest("GET", "/hello", nil)
+
+			Expect(define.ErrorCause(verifier.Verify(request))).To(Equal(define.ErrSignatureInvalid))
+		})
+	})
+})
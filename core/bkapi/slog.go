@@ -0,0 +1,240 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/TencentBlueKing/gopkg/logging"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+// LevelTrace is the slog level of the trace logs, which is lower than slog.LevelDebug.
+const LevelTrace = slog.LevelDebug - 4
+
+// slogLogger adapts a *slog.Logger to logging.Logger.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger adapts the slog logger to logging.Logger, so it can be used as ClientConfig.Logger.
+// The logs with a context carry the fields of the context, see RegisterLogContextExtractor.
+func NewSlogLogger(logger *slog.Logger) logging.Logger {
+	return &slogLogger{logger: logger}
+}
+
+func (l *slogLogger) log(ctx context.Context, level slog.Level, msg string, fields []map[string]interface{}) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	merged := internal.ContextFields(ctx)
+	for _, f := range fields {
+		for key, value := range f {
+			merged[key] = value
+		}
+	}
+
+	keys := make([]string, 0, len(merged))
+	for key := range merged {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, key := range keys {
+		attrs = append(attrs, slog.Any(key, merged[key]))
+	}
+
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// Trace logs a trace message.
+func (l *slogLogger) Trace(msg string, fields ...map[string]interface{}) {
+	l.log(context.Background(), LevelTrace, msg, fields)
+}
+
+// Debug logs a debug message.
+func (l *slogLogger) Debug(msg string, fields ...map[string]interface{}) {
+	l.log(context.Background(), slog.LevelDebug, msg, fields)
+}
+
+// Info logs an info message.
+func (l *slogLogger) Info(msg string, fields ...map[string]interface{}) {
+	l.log(context.Background(), slog.LevelInfo, msg, fields)
+}
+
+// Warn logs a warning message.
+func (l *slogLogger) Warn(msg string, fields ...map[string]interface{}) {
+	l.log(context.Background(), slog.LevelWarn, msg, fields)
+}
+
+// Error logs an error message.
+func (l *slogLogger) Error(msg string, fields ...map[string]interface{}) {
+	l.log(context.Background(), slog.LevelError, msg, fields)
+}
+
+// TraceContext logs a trace message with the context.
+func (l *slogLogger) TraceContext(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	l.log(ctx, LevelTrace, msg, fields)
+}
+
+// DebugContext logs a debug message with the context.
+func (l *slogLogger) DebugContext(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	l.log(ctx, slog.LevelDebug, msg, fields)
+}
+
+// InfoContext logs an info message with the context.
+func (l *slogLogger) InfoContext(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	l.log(ctx, slog.LevelInfo, msg, fields)
+}
+
+// WarnContext logs a warning message with the context.
+func (l *slogLogger) WarnContext(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	l.log(ctx, slog.LevelWarn, msg, fields)
+}
+
+// ErrorContext logs an error message with the context.
+func (l *slogLogger) ErrorContext(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	l.log(ctx, slog.LevelError, msg, fields)
+}
+
+// loggingHandler adapts a logging.Logger to slog.Handler.
+type loggingHandler struct {
+	logger logging.Logger
+	prefix string
+	fields map[string]interface{}
+}
+
+// NewSlogHandler adapts the logging.Logger to slog.Handler, so the slog records are written to the logger,
+// with the fields of the context, see RegisterLogContextExtractor.
+func NewSlogHandler(logger logging.Logger) slog.Handler {
+	return &loggingHandler{logger: logger, fields: map[string]interface{}{}}
+}
+
+// Enabled returns true, the levels are filtered by the logger.
+func (h *loggingHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *loggingHandler) addAttr(fields map[string]interface{}, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	if attr.Value.Kind() != slog.KindGroup {
+		fields[prefix+attr.Key] = attr.Value.Any()
+		return
+	}
+
+	// the attrs of an inline group belong to the current group
+	if attr.Key != "" {
+		prefix = prefix + attr.Key + "."
+	}
+
+	for _, a := range attr.Value.Group() {
+		h.addAttr(fields, prefix, a)
+	}
+}
+
+// Handle writes the record to the logger.
+func (h *loggingHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := internal.ContextFields(ctx)
+	for key, value := range h.fields {
+		fields[key] = value
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		h.addAttr(fields, h.prefix, attr)
+		return true
+	})
+
+	switch {
+	case record.Level < slog.LevelDebug:
+		h.logger.TraceContext(ctx, record.Message, fields)
+	case record.Level < slog.LevelInfo:
+		h.logger.DebugContext(ctx, record.Message, fields)
+	case record.Level < slog.LevelWarn:
+		h.logger.InfoContext(ctx, record.Message, fields)
+	case record.Level < slog.LevelError:
+		h.logger.WarnContext(ctx, record.Message, fields)
+	default:
+		h.logger.ErrorContext(ctx, record.Message, fields)
+	}
+
+	return nil
+}
+
+// WithAttrs returns a new handler with the attrs.
+func (h *loggingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make(map[string]interface{}, len(h.fields)+len(attrs))
+	for key, value := range h.fields {
+		fields[key] = value
+	}
+
+	for _, attr := range attrs {
+		h.addAttr(fields, h.prefix, attr)
+	}
+
+	return &loggingHandler{logger: h.logger, prefix: h.prefix, fields: fields}
+}
+
+// WithGroup returns a new handler which prefixes the keys of the later attrs with the group name.
+func (h *loggingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &loggingHandler{
+		logger: h.logger,
+		prefix: h.prefix + name + ".",
+		fields: h.fields,
+	}
+}
+
+type loggerOption struct {
+	logger logging.Logger
+}
+
+// ApplyToClient will replace the logger of the client.
+func (o *loggerOption) ApplyToClient(cli define.BkApiClient) error {
+	client, ok := cli.(interface{ SetLogger(logging.Logger) })
+	if !ok {
+		return define.ErrorWrapf(define.ErrTypeNotMatch, "client %T does not support setting the logger", cli)
+	}
+
+	client.SetLogger(o.logger)
+
+	return nil
+}
+
+// OptSlogLogger makes the client log by the slog logger, instead of ClientConfig.Logger.
+// The logs of the operations carry the client, operation, bkapi_request_id fields,
+// and the trace_id and span_id fields from the traceparent header or the context.
+func OptSlogLogger(logger *slog.Logger) define.BkApiClientOption {
+	return &loggerOption{logger: NewSlogLogger(logger)}
+}
+
+// RegisterLogContextExtractor registers a function to add the log fields from the context,
+// for example, the trace ids of the tracing library. The returned function unregisters the extractor.
+func RegisterLogContextExtractor(extractor func(ctx context.Context) map[string]interface{}) (unregister func()) {
+	return internal.RegisterContextFieldsExtractor(extractor)
+}
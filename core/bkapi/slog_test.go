@@ -0,0 +1,125 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal/mock"
+)
+
+var _ = Describe("Slog", func() {
+	var (
+		server *httptest.Server
+		buffer *bytes.Buffer
+		logger *slog.Logger
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Bkapi-Request-Id", "request-id")
+			_, _ = w.Write([]byte(`{"result":true}`))
+		}))
+
+		buffer = &bytes.Buffer{}
+		logger = slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	})
+
+	AfterEach(func() {
+		internal.ResetContextFieldsExtractors()
+		server.Close()
+	})
+
+	readRecord := func() map[string]interface{} {
+		var record map[string]interface{}
+		Expect(json.Unmarshal(buffer.Bytes(), &record)).To(Succeed())
+
+		return record
+	}
+
+	DescribeTable("should log the operations by the slog logger", func(backend define.Backend) {
+		client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+			Endpoint: server.URL,
+			Backend:  backend,
+		}, bkapi.OptSlogLogger(logger))
+		Expect(err).To(BeNil())
+
+		_, err = client.NewOperation(
+			bkapi.OperationConfig{Name: "hello", Method: "GET", Path: "/hello"},
+			bkapi.OptSetRequestHeader("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"),
+		).Request()
+		Expect(err).To(BeNil())
+
+		record := readRecord()
+		Expect(record).To(HaveKeyWithValue("level", "DEBUG"))
+		Expect(record).To(HaveKeyWithValue("msg", "request success"))
+		Expect(record).To(HaveKeyWithValue("client", "testing"))
+		Expect(record).To(HaveKeyWithValue("operation", "testing.api.hello"))
+		Expect(record).To(HaveKeyWithValue("bkapi_request_id", "request-id"))
+		Expect(record).To(HaveKeyWithValue("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"))
+		Expect(record).To(HaveKeyWithValue("span_id", "00f067aa0ba902b7"))
+	},
+		Entry("gentleman", define.BackendGentleman),
+		Entry("native", define.BackendNative),
+	)
+
+	It("should add the fields of the context", func() {
+		type traceKey struct{}
+		bkapi.RegisterLogContextExtractor(func(ctx context.Context) map[string]interface{} {
+			traceId, ok := ctx.Value(traceKey{}).(string)
+			if !ok {
+				return nil
+			}
+
+			return map[string]interface{}{"trace_id": traceId}
+		})
+
+		ctx := context.WithValue(context.Background(), traceKey{}, "trace")
+		bkapi.NewSlogLogger(logger).WarnContext(ctx, "hello", map[string]interface{}{"key": "value"})
+
+		record := readRecord()
+		Expect(record).To(HaveKeyWithValue("level", "WARN"))
+		Expect(record).To(HaveKeyWithValue("trace_id", "trace"))
+		Expect(record).To(HaveKeyWithValue("key", "value"))
+	})
+
+	It("should skip the disabled levels", func() {
+		bkapi.NewSlogLogger(logger).Trace("hello")
+		Expect(buffer.Len()).To(Equal(0))
+	})
+
+	It("should write the slog records to the logger", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockLogger := mock.NewMockLogger(ctrl)
+		mockLogger.EXPECT().ErrorContext(gomock.Any(), "failed", map[string]interface{}{
+			"client":        "testing",
+			"request.path":  "/hello",
+			"request.retry": int64(1),
+		})
+
+		handler := bkapi.NewSlogHandler(mockLogger)
+		slog.New(handler).With("client", "testing").WithGroup("request").
+			Error("failed", "path", "/hello", slog.Group("", "retry", 1))
+	})
+})
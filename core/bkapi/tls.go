@@ -0,0 +1,86 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/TencentBlueKing/gopkg/logging"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+type mtlsOption struct {
+	certFile string
+	keyFile  string
+	caFile   string
+}
+
+// ApplyToClient will load the certificates and apply them to the client transport.
+func (o *mtlsOption) ApplyToClient(cli define.BkApiClient) error {
+	var logger logging.Logger
+	if provider, ok := cli.(interface{ Logger() logging.Logger }); ok {
+		logger = provider.Logger()
+	}
+
+	var (
+		loader *internal.CertificateLoader
+		roots  *x509.CertPool
+		err    error
+	)
+
+	if o.certFile != "" || o.keyFile != "" {
+		loader, err = internal.NewCertificateLoader(o.certFile, o.keyFile, logger, 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.caFile != "" {
+		roots, err = internal.LoadCertPool(o.caFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	return transportHookOption(func(transport *http.Transport) {
+		config := &tls.Config{}
+		if transport.TLSClientConfig != nil {
+			config = transport.TLSClientConfig.Clone()
+		}
+
+		if loader != nil {
+			config.GetClientCertificate = loader.GetClientCertificate
+		}
+
+		if roots != nil {
+			config.RootCAs = roots
+		}
+
+		transport.TLSClientConfig = config
+	}).ApplyToClient(cli)
+}
+
+// OptMTLSFromFiles enables the mutual TLS by the PEM files, the certificate and key files are reloaded
+// when they are changed, so the rotated certificate is used by the new connections.
+// The expiry warnings are logged by the client logger. The CA file is optional and loaded once,
+// it replaces the system root CAs to verify the server; leave the certificate and key files empty to set the CA only.
+func OptMTLSFromFiles(certFile, keyFile, caFile string) define.BkApiClientOption {
+	return &mtlsOption{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+	}
+}
@@ -0,0 +1,194 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package bkapi_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+type testingCertificateAuthority struct {
+	key         *ecdsa.PrivateKey
+	certificate *x509.Certificate
+	der         []byte
+}
+
+func newTestingCertificateAuthority() *testingCertificateAuthority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(BeNil())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "testing ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour * 24 * 30),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).To(BeNil())
+
+	certificate, err := x509.ParseCertificate(der)
+	Expect(err).To(BeNil())
+
+	return &testingCertificateAuthority{key: key, certificate: certificate, der: der}
+}
+
+// issue returns the PEM encoded certificate and key signed by the CA
+func (ca *testingCertificateAuthority) issue(serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(BeNil())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "testing"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour * 24 * 30),
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.certificate, &key.PublicKey, ca.key)
+	Expect(err).To(BeNil())
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).To(BeNil())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+var _ = Describe("TLS", func() {
+	var (
+		ca       *testingCertificateAuthority
+		server   *httptest.Server
+		certFile string
+		keyFile  string
+		caFile   string
+	)
+
+	writeClientCertificate := func(serial int64) {
+		certPem, keyPem := ca.issue(serial, x509.ExtKeyUsageClientAuth)
+		Expect(os.WriteFile(certFile, certPem, 0o600)).To(Succeed())
+		Expect(os.WriteFile(keyFile, keyPem, 0o600)).To(Succeed())
+
+		// make sure the modification time is changed
+		modTime := time.Now().Add(time.Duration(serial) * time.Second)
+		Expect(os.Chtimes(certFile, modTime, modTime)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ca = newTestingCertificateAuthority()
+
+		dir := GinkgoT().TempDir()
+		certFile = filepath.Join(dir, "client.crt")
+		keyFile = filepath.Join(dir, "client.key")
+		caFile = filepath.Join(dir, "ca.crt")
+		Expect(os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.der}), 0o600)).
+			To(Succeed())
+
+		serverCert, serverKey := ca.issue(100, x509.ExtKeyUsageServerAuth)
+		certificate, err := tls.X509KeyPair(serverCert, serverKey)
+		Expect(err).To(BeNil())
+
+		clientCAs := x509.NewCertPool()
+		clientCAs.AddCert(ca.certificate)
+
+		server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Client-Serial", r.TLS.PeerCertificates[0].SerialNumber.String())
+			w.WriteHeader(http.StatusOK)
+		}))
+		server.TLS = &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    clientCAs,
+		}
+		server.StartTLS()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	request := func(client define.BkApiClient) string {
+		response, err := client.NewOperation(bkapi.OperationConfig{Method: "GET", Path: "/"}).Request()
+		Expect(err).To(BeNil())
+
+		return response.Header.Get("X-Client-Serial")
+	}
+
+	DescribeTable("should use the reloaded client certificate", func(backend define.Backend) {
+		writeClientCertificate(1)
+
+		client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+			Endpoint: server.URL,
+			Backend:  backend,
+		}, bkapi.OptMTLSFromFiles(certFile, keyFile, caFile), bkapi.OptKeepAlive(false))
+		Expect(err).To(BeNil())
+		defer client.Close()
+
+		Expect(request(client)).To(Equal("1"))
+
+		writeClientCertificate(2)
+		Expect(request(client)).To(Equal("2"))
+	},
+		Entry("gentleman", define.BackendGentleman),
+		Entry("native", define.BackendNative),
+	)
+
+	It("should enable the mutual TLS by env", func() {
+		writeClientCertificate(1)
+
+		env := map[string]string{
+			"BK_API_TLS_CERT_FILE": certFile,
+			"BK_API_TLS_KEY_FILE":  keyFile,
+			"BK_API_TLS_CA_FILE":   caFile,
+		}
+		client, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+			Endpoint: server.URL,
+			Getenv: func(key string) string {
+				return env[key]
+			},
+		})
+		Expect(err).To(BeNil())
+		defer client.Close()
+
+		Expect(request(client)).To(Equal("1"))
+	})
+
+	It("should fail when the certificate files are not found", func() {
+		_, err := bkapi.NewBkApiClient("testing", bkapi.ClientConfig{
+			Endpoint:    server.URL,
+			TLSCertFile: certFile,
+			TLSKeyFile:  keyFile,
+		})
+		Expect(err).NotTo(BeNil())
+	})
+})
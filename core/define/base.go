@@ -16,4 +16,8 @@ const (
 	UserAgent = "bkapi-client-golang"
 	// Version is the version of the package.
 	Version = "0.0.0"
+	// IdempotencyKeyHeader is the header to carry the idempotency key of an operation.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// RequestTimeoutHeader is the header to propagate the remaining time budget of a request in milliseconds.
+	RequestTimeoutHeader = "X-Request-Timeout"
 )
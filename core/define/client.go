@@ -11,6 +11,8 @@
 
 package define
 
+import "time"
+
 //go:generate mockgen -source=$GOFILE -destination=../internal/mock/$GOFILE -package=mock BkApiClient,BkApiClientOption
 //go:generate mockgen -destination=../internal/mock/http.go -package=mock net/http RoundTripper
 //go:generate mockgen -destination=../internal/mock/io.go -package=mock io ReadCloser
@@ -28,6 +30,28 @@ type BkApiClient interface {
 
 	// NewOperation method creates a new operation dynamically and apply the given options.
 	NewOperation(config OperationConfigProvider, opts ...OperationOption) Operation
+
+	// Close method releases the idle connections of the client,
+	// the shared connections are released when all the clients using them are closed.
+	Close() error
+}
+
+// ConnectionStats is the connection reuse statistics of a client.
+type ConnectionStats struct {
+	// Requests is the number of the connections obtained by the requests.
+	Requests int64
+	// NewConns is the number of the newly dialed connections.
+	NewConns int64
+	// ReusedConns is the number of the reused connections.
+	ReusedConns int64
+	// IdleTime is the total time the reused connections spent in the idle pool.
+	IdleTime time.Duration
+}
+
+// ConnectionStatsProvider is an optional extension of BkApiClient to expose the connection reuse statistics.
+type ConnectionStatsProvider interface {
+	// ConnectionStats returns the connection reuse statistics.
+	ConnectionStats() ConnectionStats
 }
 
 // BkApiClientOption defines the interface of BkApi client option.
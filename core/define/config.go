@@ -11,7 +11,11 @@
 
 package define
 
-import "github.com/TencentBlueKing/gopkg/logging"
+import (
+	"time"
+
+	"github.com/TencentBlueKing/gopkg/logging"
+)
 
 //go:generate mockgen -source=$GOFILE -destination=../internal/mock/$GOFILE -package=mock ClientConfig,ClientConfigProvider,OperationConfig,OperationConfigProvider
 //go:generate mockgen -destination=../internal/mock/logging.go -package=mock github.com/TencentBlueKing/gopkg/logging Logger
@@ -30,6 +34,44 @@ type ClientConfig interface {
 	GetClientOptions() []BkApiClientOption
 }
 
+// Backend is the implementation which sends the requests of a client.
+type Backend string
+
+const (
+	// BackendGentleman sends the requests by gentleman, it is the default backend.
+	BackendGentleman Backend = "gentleman"
+	// BackendNative sends the requests by net/http directly, it has less overhead for each request.
+	BackendNative Backend = "native"
+)
+
+// BackendConfig is an optional extension of ClientConfig to choose the backend of the client.
+type BackendConfig interface {
+	// GetBackend returns the backend of the client, empty means the default backend.
+	GetBackend() Backend
+}
+
+// TransportConfig is an optional extension of ClientConfig to share the transport between the clients.
+type TransportConfig interface {
+	// IsTransportShared returns whether the client shares the transport with the clients of the same endpoint.
+	IsTransportShared() bool
+}
+
+// SignatureConfig is an optional extension of ClientConfig to sign the requests.
+type SignatureConfig interface {
+	// GetSignatureKey returns the HMAC key to sign the requests, empty means the requests are not signed.
+	GetSignatureKey() string
+}
+
+// DebugConfig is an optional extension of ClientConfig to dump the wire traffic for debugging.
+type DebugConfig interface {
+	// GetDebug returns the client name patterns to dump, empty means disabled.
+	GetDebug() string
+	// GetDebugFile returns the file to write the dumps, empty means the client logger.
+	GetDebugFile() string
+	// GetDebugBodyLimit returns the maximum bytes of the body to dump, zero means the default limit.
+	GetDebugBodyLimit() int
+}
+
 // ClientConfigProvider should provide a ClientConfig instance.
 type ClientConfigProvider interface {
 	// ProvideConfig returns a ClientConfig instance.
@@ -46,6 +88,28 @@ type OperationConfig interface {
 	GetPath() string
 }
 
+// RetryPolicy defines how to retry an operation.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+	// Backoff is the waiting duration before the first retry, and it will be doubled for each retry.
+	Backoff time.Duration
+	// MaxBackoff limits the backoff duration, zero means no limit.
+	MaxBackoff time.Duration
+	// RetryOnStatus are the response status codes to retry, defaults to 502, 503 and 504.
+	RetryOnStatus []int
+}
+
+// OperationPolicyConfig is an optional extension of OperationConfig to provide the execution policies.
+type OperationPolicyConfig interface {
+	// GetTimeout returns the default timeout of the operation, zero means no default timeout.
+	GetTimeout() time.Duration
+	// IsIdempotent returns whether the operation is idempotent.
+	IsIdempotent() bool
+	// GetRetryPolicy returns the retry policy of the operation, nil means no retry.
+	GetRetryPolicy() *RetryPolicy
+}
+
 // OperationConfigProvider should provide a OperationConfig instance.
 type OperationConfigProvider interface {
 	// ProvideConfig returns a OperationConfig instance.
@@ -13,6 +13,9 @@ package define
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
 
 	pkgErrors "github.com/pkg/errors"
 )
@@ -24,11 +27,23 @@ var (
 	ErrBkApiRequest = errors.New("bkapi request error")
 	// ErrConfigInvalid defines the error which indicates the config is invalid.
 	ErrConfigInvalid = errors.New("config invalid")
+	// ErrMissingPathParam defines the error which indicates some path parameters are missing.
+	ErrMissingPathParam = errors.New("missing path param")
+	// ErrSignatureInvalid defines the error which indicates the signature of the request is invalid.
+	ErrSignatureInvalid = errors.New("signature invalid")
 )
 
+// ErrorWrapfFunc annotates err with the format specifier and arguments.
+type ErrorWrapfFunc func(err error, format string, args ...interface{}) error
+
+var errorWrapf atomic.Value
+
 var (
-	// ErrorWrapf annotates err with the format specifier and arguments.
-	ErrorWrapf = pkgErrors.WithMessagef
+	// ErrorWrapf annotates err with the format specifier and arguments, the implementation is set by SetErrorWrapf.
+	// Assigning to it directly still works but is not thread safe, use SetErrorWrapf instead.
+	ErrorWrapf = func(err error, format string, args ...interface{}) error {
+		return errorWrapf.Load().(ErrorWrapfFunc)(err, format, args...)
+	}
 	// ErrorCause returns the underlying cause of the error, if possible.
 	ErrorCause = pkgErrors.Cause
 )
@@ -38,10 +53,14 @@ func EnableStackTraceErrorWrapf() {
 	SetErrorWrapf(pkgErrors.Wrapf)
 }
 
-// SetErrorWrapf sets the ErrorWrapf.
-// This function is not thread safe, do not call it in parallel.
+// SetErrorWrapf sets the implementation of ErrorWrapf, it is safe to call it concurrently.
 func SetErrorWrapf(f func(err error, format string, args ...interface{}) error) {
-	ErrorWrapf = f
+	errorWrapf.Store(ErrorWrapfFunc(f))
+}
+
+// ResetErrorWrapf restores the default implementation of ErrorWrapf, which has no stack trace.
+func ResetErrorWrapf() {
+	SetErrorWrapf(pkgErrors.WithMessagef)
 }
 
 // BkApiRequestError is the error returned by api gateway.
@@ -51,3 +70,34 @@ type BkApiRequestError interface {
 	ErrorCode() string
 	ErrorMessage() string
 }
+
+// IdempotencyKeyError is the error returned by an operation which has an idempotency key.
+type IdempotencyKeyError interface {
+	error
+	IdempotencyKey() string
+}
+
+// MissingPathParamError is the error returned when some placeholders of the path have no values.
+type MissingPathParamError struct {
+	// Keys are the names of the missing path parameters.
+	Keys []string
+}
+
+// Error renders the error message.
+func (e *MissingPathParamError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrMissingPathParam, strings.Join(e.Keys, ", "))
+}
+
+// Cause always return ErrMissingPathParam
+func (e *MissingPathParamError) Cause() error {
+	return ErrMissingPathParam
+}
+
+// Unwrap always return ErrMissingPathParam
+func (e *MissingPathParamError) Unwrap() error {
+	return ErrMissingPathParam
+}
+
+func init() {
+	ResetErrorWrapf()
+}
@@ -0,0 +1,57 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package define
+
+import (
+	"context"
+	"net/http"
+)
+
+// RoundTrip sends a request and returns the response, it is the minimal unit of a request execution.
+type RoundTrip func(request *http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTrip to customize the request execution, like logging, metrics and retrying.
+// It works on all the client backends.
+//
+// The middlewares are called in the order of registration: the global ones, the client ones and then
+// the operation ones, the former is the outer one. A middleware receives the final request, the auth headers,
+// the request options and the body provider have been applied. The response body has not been read,
+// the result provider will decode it after all the middlewares return. When the operation is retried,
+// each attempt goes through the middlewares. The request signing and the debug dumping are always inside
+// all the middlewares, so the changes of the middlewares are signed and dumped.
+type Middleware func(next RoundTrip) RoundTrip
+
+// OperationMetadata describes the operation of a request.
+type OperationMetadata struct {
+	// ClientName is the name of the client, which is the api name.
+	ClientName string
+	// Name is the operation name.
+	Name string
+	// FullName is the full name of the operation, like "<client>.api.<name>".
+	FullName string
+	// Path is the path template of the operation, like "/users/{id}/".
+	Path string
+}
+
+type operationMetadataKey struct{}
+
+// WithOperationMetadata returns a new context carrying the operation metadata.
+func WithOperationMetadata(ctx context.Context, metadata OperationMetadata) context.Context {
+	return context.WithValue(ctx, operationMetadataKey{}, metadata)
+}
+
+// OperationMetadataFromContext returns the operation metadata of the request,
+// it is available in the request context of the middlewares.
+func OperationMetadataFromContext(ctx context.Context) (OperationMetadata, bool) {
+	metadata, ok := ctx.Value(operationMetadataKey{}).(OperationMetadata)
+	return metadata, ok
+}
@@ -77,6 +77,15 @@ type Operation interface {
 
 	// Request method sends the operation request and returns the response.
 	Request() (*http.Response, error)
+
+	// Build method runs all the request plugins, auth headers and body providers,
+	// and returns the final request without sending it.
+	// Like Request, an operation can only be built once.
+	Build(ctx context.Context) (*http.Request, error)
+
+	// Curl method builds the operation and renders the final request as a curl command,
+	// the secrets in the request headers will be masked.
+	Curl() (string, error)
 }
 
 // OperationOption defines the option of the operation.
@@ -0,0 +1,198 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/TencentBlueKing/gopkg/logging"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+const (
+	// DefaultCertificateExpiryWarning is the remaining lifetime of a certificate to warn about the expiry.
+	DefaultCertificateExpiryWarning = 24 * time.Hour
+	// the minimal interval between two expiry warnings
+	certificateWarningInterval = time.Hour
+)
+
+// CertificateLoader loads the client certificate from files, and reloads it when the files are changed.
+type CertificateLoader struct {
+	certFile      string
+	keyFile       string
+	logger        logging.Logger
+	expiryWarning time.Duration
+
+	lock         sync.Mutex
+	certificate  *tls.Certificate
+	certModTime  time.Time
+	keyModTime   time.Time
+	lastWarnedAt time.Time
+}
+
+func (l *CertificateLoader) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(l.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	keyInfo, err := os.Stat(l.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
+
+// reload loads the certificate when the files are changed, the lock should be held by the caller.
+func (l *CertificateLoader) reload() error {
+	certModTime, keyModTime, err := l.modTimes()
+	if err != nil {
+		return define.ErrorWrapf(err, "failed to stat the client certificate %s", l.certFile)
+	}
+
+	if l.certificate != nil && certModTime.Equal(l.certModTime) && keyModTime.Equal(l.keyModTime) {
+		return nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		return define.ErrorWrapf(err, "failed to load the client certificate %s", l.certFile)
+	}
+
+	if certificate.Leaf == nil {
+		certificate.Leaf, err = x509.ParseCertificate(certificate.Certificate[0])
+		if err != nil {
+			return define.ErrorWrapf(err, "failed to parse the client certificate %s", l.certFile)
+		}
+	}
+
+	l.certificate = &certificate
+	l.certModTime = certModTime
+	l.keyModTime = keyModTime
+	l.lastWarnedAt = time.Time{}
+
+	if l.logger != nil {
+		l.logger.Info("client certificate loaded", map[string]interface{}{
+			"cert_file": l.certFile,
+			"not_after": certificate.Leaf.NotAfter,
+		})
+	}
+
+	return nil
+}
+
+// checkExpiry warns when the certificate is going to expire, the lock should be held by the caller.
+func (l *CertificateLoader) checkExpiry() {
+	if l.logger == nil || l.certificate == nil {
+		return
+	}
+
+	now := time.Now()
+	if !l.lastWarnedAt.IsZero() && now.Sub(l.lastWarnedAt) < certificateWarningInterval {
+		return
+	}
+
+	notAfter := l.certificate.Leaf.NotAfter
+	fields := map[string]interface{}{
+		"cert_file": l.certFile,
+		"not_after": notAfter,
+	}
+
+	switch {
+	case now.After(notAfter):
+		l.logger.Error("client certificate has expired", fields)
+	case notAfter.Sub(now) < l.expiryWarning:
+		l.logger.Warn("client certificate is going to expire", fields)
+	default:
+		return
+	}
+
+	l.lastWarnedAt = now
+}
+
+// Certificate returns the current certificate, it is reloaded when the files are changed.
+// The previous certificate is kept when the reloading fails.
+func (l *CertificateLoader) Certificate() (*tls.Certificate, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	err := l.reload()
+	if err != nil {
+		if l.certificate == nil {
+			return nil, err
+		}
+
+		if l.logger != nil {
+			l.logger.Error("failed to reload client certificate, keep using the previous one", map[string]interface{}{
+				"cert_file": l.certFile,
+				"error":     err.Error(),
+			})
+		}
+	}
+
+	l.checkExpiry()
+
+	return l.certificate, nil
+}
+
+// GetClientCertificate implements tls.Config.GetClientCertificate.
+func (l *CertificateLoader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return l.Certificate()
+}
+
+// NewCertificateLoader creates a CertificateLoader and loads the certificate immediately.
+// The expiry warnings are logged when the remaining lifetime is less than expiryWarning,
+// zero means DefaultCertificateExpiryWarning.
+func NewCertificateLoader(
+	certFile, keyFile string,
+	logger logging.Logger,
+	expiryWarning time.Duration,
+) (*CertificateLoader, error) {
+	if expiryWarning <= 0 {
+		expiryWarning = DefaultCertificateExpiryWarning
+	}
+
+	loader := &CertificateLoader{
+		certFile:      certFile,
+		keyFile:       keyFile,
+		logger:        logger,
+		expiryWarning: expiryWarning,
+	}
+
+	_, err := loader.Certificate()
+	if err != nil {
+		return nil, err
+	}
+
+	return loader, nil
+}
+
+// LoadCertPool loads the CA certificates from the PEM file.
+func LoadCertPool(caFile string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, define.ErrorWrapf(err, "failed to read the CA file %s", caFile)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, define.ErrorWrapf(define.ErrConfigInvalid, "no certificate found in the CA file %s", caFile)
+	}
+
+	return pool, nil
+}
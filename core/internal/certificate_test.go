@@ -0,0 +1,142 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal/mock"
+)
+
+// writeSelfSignedCertificate writes a self-signed certificate and its key to the files
+func writeSelfSignedCertificate(certFile, keyFile string, serial int64, notAfter time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(BeNil())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "testing"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).To(BeNil())
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).To(BeNil())
+
+	Expect(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)).
+		To(Succeed())
+	Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600)).
+		To(Succeed())
+}
+
+var _ = Describe("CertificateLoader", func() {
+	var (
+		ctrl     *gomock.Controller
+		logger   *mock.MockLogger
+		certFile string
+		keyFile  string
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		logger = mock.NewMockLogger(ctrl)
+		logger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+		dir := GinkgoT().TempDir()
+		certFile = filepath.Join(dir, "client.crt")
+		keyFile = filepath.Join(dir, "client.key")
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should fail when the files are not found", func() {
+		_, err := internal.NewCertificateLoader(certFile, keyFile, logger, 0)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should reload the certificate when the files are changed", func() {
+		writeSelfSignedCertificate(certFile, keyFile, 1, time.Now().Add(30*24*time.Hour))
+
+		loader, err := internal.NewCertificateLoader(certFile, keyFile, logger, 0)
+		Expect(err).To(BeNil())
+
+		certificate, err := loader.GetClientCertificate(nil)
+		Expect(err).To(BeNil())
+		Expect(certificate.Leaf.SerialNumber.Int64()).To(Equal(int64(1)))
+
+		writeSelfSignedCertificate(certFile, keyFile, 2, time.Now().Add(30*24*time.Hour))
+		future := time.Now().Add(time.Minute)
+		Expect(os.Chtimes(certFile, future, future)).To(Succeed())
+
+		certificate, err = loader.GetClientCertificate(nil)
+		Expect(err).To(BeNil())
+		Expect(certificate.Leaf.SerialNumber.Int64()).To(Equal(int64(2)))
+	})
+
+	It("should keep the previous certificate when the reloading fails", func() {
+		writeSelfSignedCertificate(certFile, keyFile, 1, time.Now().Add(30*24*time.Hour))
+
+		loader, err := internal.NewCertificateLoader(certFile, keyFile, logger, 0)
+		Expect(err).To(BeNil())
+
+		logger.EXPECT().Error(gomock.Any(), gomock.Any())
+		Expect(os.WriteFile(certFile, []byte("broken"), 0o600)).To(Succeed())
+		future := time.Now().Add(time.Minute)
+		Expect(os.Chtimes(certFile, future, future)).To(Succeed())
+
+		certificate, err := loader.Certificate()
+		Expect(err).To(BeNil())
+		Expect(certificate.Leaf.SerialNumber.Int64()).To(Equal(int64(1)))
+	})
+
+	It("should warn when the certificate is going to expire", func() {
+		writeSelfSignedCertificate(certFile, keyFile, 1, time.Now().Add(time.Hour))
+
+		// the warning is logged once in an interval
+		logger.EXPECT().Warn("client certificate is going to expire", gomock.Any()).Times(1)
+
+		loader, err := internal.NewCertificateLoader(certFile, keyFile, logger, 0)
+		Expect(err).To(BeNil())
+
+		_, err = loader.Certificate()
+		Expect(err).To(BeNil())
+	})
+
+	It("should load the CA certificates", func() {
+		writeSelfSignedCertificate(certFile, keyFile, 1, time.Now().Add(time.Hour))
+
+		pool, err := internal.LoadCertPool(certFile)
+		Expect(err).To(BeNil())
+		Expect(pool).NotTo(BeNil())
+
+		_, err = internal.LoadCertPool(keyFile)
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -12,13 +12,14 @@
 package internal
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/TencentBlueKing/gopkg/logging"
 	gentleman "gopkg.in/h2non/gentleman.v2"
-	"gopkg.in/h2non/gentleman.v2/context"
+	gmctx "gopkg.in/h2non/gentleman.v2/context"
 	"gopkg.in/h2non/gentleman.v2/plugin"
 	"gopkg.in/h2non/gentleman.v2/plugins/headers"
 
@@ -35,6 +36,8 @@ type BkApiClient struct {
 	name             string
 	logger           logging.Logger
 	client           *gentleman.Client
+	httpClient       *http.Client
+	pool             *ConnectionPool
 	operationOptions []define.OperationOption
 	operationFactory func(name string, client define.BkApiClient, request *gentleman.Request) define.Operation
 }
@@ -44,6 +47,16 @@ func (cli *BkApiClient) Name() string {
 	return cli.name
 }
 
+// Logger returns the client logger.
+func (cli *BkApiClient) Logger() logging.Logger {
+	return cli.logger
+}
+
+// SetLogger replaces the client logger, nil disables the logging.
+func (cli *BkApiClient) SetLogger(logger logging.Logger) {
+	cli.logger = logger
+}
+
 // Apply method applies the given options to the client.
 func (cli *BkApiClient) Apply(opts ...define.BkApiClientOption) error {
 	for _, opt := range opts {
@@ -64,20 +77,95 @@ func (cli *BkApiClient) AddOperationOptions(opts ...define.OperationOption) erro
 	return nil
 }
 
+// UseConnectionPool makes the client send the requests by the transport of the pool.
+// The http client options applied to the client afterwards modify a client level http client once,
+// instead of the http client of each request.
+func (cli *BkApiClient) UseConnectionPool(pool *ConnectionPool) {
+	if cli.httpClient == nil {
+		cli.httpClient = &http.Client{}
+		cli.client.Use(plugin.NewRequestPlugin(func(ctx *gmctx.Context, h gmctx.Handler) {
+			*ctx.Client = *cli.httpClient
+			ctx.Request = cli.pool.TraceRequest(ctx.Request)
+			h.Next(ctx)
+		}))
+	}
+
+	if cli.pool != nil && cli.pool != pool {
+		cli.pool.Release()
+	}
+
+	cli.pool = pool
+	cli.httpClient.Transport = pool.RoundTripper()
+}
+
+// hookTransport configures the transport of the client, the client owns the transport of the pool before that.
+func (cli *BkApiClient) hookTransport(hook TransportHook) error {
+	if cli.httpClient.Transport == cli.pool.RoundTripper() {
+		cli.UseConnectionPool(cli.pool.Own())
+	}
+
+	// if using a custom transport, just ignore it
+	transport, ok := cli.httpClient.Transport.(*http.Transport)
+	if ok {
+		hook(transport)
+	}
+
+	return nil
+}
+
+// ConnectionStats returns the connection reuse statistics of the client.
+func (cli *BkApiClient) ConnectionStats() define.ConnectionStats {
+	if cli.pool == nil {
+		return define.ConnectionStats{}
+	}
+
+	return cli.pool.Stats()
+}
+
+// Close method releases the connection pool of the client, it is safe to call it multiple times.
+func (cli *BkApiClient) Close() error {
+	if cli.pool != nil {
+		cli.pool.Release()
+	}
+
+	return nil
+}
+
 func (cli *BkApiClient) logResponse(op define.Operation, response *http.Response) {
-	logger := cli.logger
+	logOperationResponse(cli.logger, op, response)
+}
+
+func logOperationResponse(logger logging.Logger, op define.Operation, response *http.Response) {
 	if logger == nil {
 		return
 	}
 
-	details := NewBkApiResponseDetailFromResponse(response)
-	fields := details.Map()
+	// a custom transport may not set the request of the response
+	ctx := context.Background()
+	if response.Request != nil {
+		ctx = response.Request.Context()
+	}
 
-	ctx := response.Request.Context()
+	fields := ContextFields(ctx)
+	if response.Request != nil {
+		for key, value := range TraceFieldsFromHeader(response.Request.Header) {
+			fields[key] = value
+		}
+	}
+
+	for key, value := range NewBkApiResponseDetailFromResponse(response).Map() {
+		fields[key] = value
+	}
+
+	fields["client"] = op.ClientName()
 	fields["operation"] = op
 	fields["status"] = response.Status
 	fields["status_code"] = response.StatusCode
 
+	if operation, ok := op.(PolicyOperation); ok && operation.IdempotencyKey() != "" {
+		fields["idempotency_key"] = operation.IdempotencyKey()
+	}
+
 	switch response.StatusCode / 100 {
 	case 4:
 		logger.WarnContext(ctx, "request error caused by client", fields)
@@ -92,14 +180,14 @@ func (cli *BkApiClient) newGentlemanRequest(config define.OperationConfig) *gent
 	return cli.client.Request().
 		Method(config.GetMethod()).
 		Use(headers.Set("User-Agent", DefaultUserAgent)).
-		Use(plugin.NewRequestPlugin(func(c *context.Context, h context.Handler) {
+		Use(plugin.NewRequestPlugin(func(c *gmctx.Context, h gmctx.Handler) {
 			path := strings.TrimSuffix(c.Request.URL.Path, "/")
 			c.Request.URL.Path = fmt.Sprintf("%s/%s", path, strings.TrimPrefix(config.GetPath(), "/"))
 			h.Next(c)
 		}))
 }
 
-func (cli *BkApiClient) newOperationName(config define.OperationConfig) string {
+func newOperationName(config define.OperationConfig) string {
 	name := config.GetName()
 	if name != "" {
 		return name
@@ -125,14 +213,19 @@ func (cli *BkApiClient) NewOperation(
 ) define.Operation {
 	config := provider.ProvideConfig()
 	request := cli.newGentlemanRequest(config)
-	name := cli.newOperationName(config)
+	name := newOperationName(config)
 	operation := cli.operationFactory(name, cli, request)
+	if op, ok := operation.(*Operation); ok {
+		op.path = config.GetPath()
+	}
 
-	request.Use(plugin.NewResponsePlugin(func(c *context.Context, h context.Handler) {
+	request.Use(plugin.NewResponsePlugin(func(c *gmctx.Context, h gmctx.Handler) {
 		cli.logResponse(operation, c.Response)
 		h.Next(c)
 	}))
 
+	// the policy should be applied first, so it can be overridden by the options
+	applyOperationPolicy(operation, config)
 	cli.applyOperationOptions(operation, opts...)
 
 	return operation
@@ -85,6 +85,7 @@ var _ = Describe("Client", func() {
 				"testing", gentlemanClient,
 				func(name string, client define.BkApiClient, req *gentleman.Request) define.Operation {
 					operation.EXPECT().Name().Return(name).AnyTimes()
+					operation.EXPECT().ClientName().Return("testing").AnyTimes()
 
 					request = req
 					return operation
@@ -203,6 +204,7 @@ var _ = Describe("Client", func() {
 					"testing", gentlemanClient,
 					func(name string, client define.BkApiClient, req *gentleman.Request) define.Operation {
 						operation.EXPECT().Name().Return(name).AnyTimes()
+						operation.EXPECT().ClientName().Return("testing").AnyTimes()
 
 						request = req
 						return operation
@@ -0,0 +1,88 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+func quoteShellArgument(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// ReadRequestBody reads the whole request body and restores it, so the request can still be sent.
+func ReadRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err == nil {
+			defer body.Close()
+			return ioutil.ReadAll(body)
+		}
+	}
+
+	content, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	request.Body.Close()
+
+	request.Body = ioutil.NopCloser(bytes.NewReader(content))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(content)), nil
+	}
+
+	return content, nil
+}
+
+// NewCurlCommand renders the request as a curl command,
+// the sensitive header values will be masked when mask is true.
+func NewCurlCommand(request *http.Request, mask bool) (string, error) {
+	header := request.Header
+	if mask {
+		header = MaskHeader(header)
+	}
+
+	parts := []string{"curl", "-X", quoteShellArgument(request.Method), quoteShellArgument(request.URL.String())}
+
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range header[key] {
+			parts = append(parts, "-H", quoteShellArgument(fmt.Sprintf("%s: %s", key, value)))
+		}
+	}
+
+	body, err := ReadRequestBody(request)
+	if err != nil {
+		return "", err
+	}
+
+	if len(body) > 0 {
+		parts = append(parts, "--data-binary", quoteShellArgument(string(body)))
+	}
+
+	return strings.Join(parts, " "), nil
+}
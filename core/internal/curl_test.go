@@ -0,0 +1,62 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+var _ = Describe("Curl", func() {
+	var request *http.Request
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("POST", "http://example.com/api?foo=bar", strings.NewReader(`{"it's":"ok"}`))
+		Expect(err).To(BeNil())
+
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-Bkapi-Authorization", `{"access_token":"token"}`)
+	})
+
+	It("should render the curl command with secrets masked", func() {
+		command, err := internal.NewCurlCommand(request, true)
+		Expect(err).To(BeNil())
+		Expect(command).To(Equal(
+			`curl -X 'POST' 'http://example.com/api?foo=bar' ` +
+				`-H 'Content-Type: application/json' ` +
+				`-H 'X-Bkapi-Authorization: {"access_token":"******"}' ` +
+				`--data-binary '{"it'\''s":"ok"}'`,
+		))
+	})
+
+	It("should render the curl command without masking", func() {
+		command, err := internal.NewCurlCommand(request, false)
+		Expect(err).To(BeNil())
+		Expect(command).To(ContainSubstring(`{"access_token":"token"}`))
+	})
+
+	It("should keep the request body readable", func() {
+		_, err := internal.NewCurlCommand(request, true)
+		Expect(err).To(BeNil())
+
+		body, err := ioutil.ReadAll(request.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(Equal(`{"it's":"ok"}`))
+	})
+})
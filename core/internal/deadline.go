@@ -0,0 +1,82 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+// GetRequestTimeBudget returns the remaining time of the request, which is limited by the context deadline
+// and the client timeout, the ok is false when there is no limitation.
+func GetRequestTimeBudget(request *http.Request, clientTimeout time.Duration) (budget time.Duration, ok bool) {
+	if clientTimeout > 0 {
+		budget, ok = clientTimeout, true
+	}
+
+	deadline, hasDeadline := request.Context().Deadline()
+	if hasDeadline {
+		remaining := time.Until(deadline)
+		if !ok || remaining < budget {
+			budget, ok = remaining, true
+		}
+	}
+
+	return budget, ok
+}
+
+// SetRequestTimeoutHeader propagates the remaining time budget of the request to the backend by header,
+// an existing header will not be overwritten.
+func SetRequestTimeoutHeader(request *http.Request, clientTimeout time.Duration) {
+	if request.Header.Get(define.RequestTimeoutHeader) != "" {
+		return
+	}
+
+	budget, ok := GetRequestTimeBudget(request, clientTimeout)
+	if !ok || budget <= 0 {
+		return
+	}
+
+	request.Header.Set(define.RequestTimeoutHeader, strconv.FormatInt(int64(budget/time.Millisecond), 10))
+}
+
+// NewRequestTimeoutMiddleware propagates the remaining time budget by header on each attempt of the retries.
+// The client timeout counts from the creation of the middleware, so the later attempts advertise the time left
+// instead of the whole timeout. An existing header will not be overwritten.
+func NewRequestTimeoutMiddleware(clientTimeout time.Duration) define.Middleware {
+	startedAt := time.Now()
+
+	return func(next define.RoundTrip) define.RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+			if request.Header.Get(define.RequestTimeoutHeader) != "" {
+				return next(request)
+			}
+
+			remaining := clientTimeout
+			if clientTimeout > 0 {
+				remaining -= time.Since(startedAt)
+				if remaining <= 0 {
+					return next(request)
+				}
+			}
+
+			// the round tripper should not modify the original request
+			attempt := request.Clone(request.Context())
+			SetRequestTimeoutHeader(attempt, remaining)
+
+			return next(attempt)
+		}
+	}
+}
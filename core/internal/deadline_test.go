@@ -0,0 +1,87 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+var _ = Describe("Deadline", func() {
+	var request *http.Request
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "http://example.com/api", nil)
+		Expect(err).To(BeNil())
+	})
+
+	It("should not set the header when there is no limitation", func() {
+		internal.SetRequestTimeoutHeader(request, 0)
+
+		Expect(request.Header.Get(define.RequestTimeoutHeader)).To(Equal(""))
+	})
+
+	It("should set the header by client timeout", func() {
+		internal.SetRequestTimeoutHeader(request, 3*time.Second)
+
+		Expect(request.Header.Get(define.RequestTimeoutHeader)).To(Equal("3000"))
+	})
+
+	It("should set the header by the remaining time of context", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		request = request.WithContext(ctx)
+		internal.SetRequestTimeoutHeader(request, time.Minute)
+
+		timeout, err := strconv.Atoi(request.Header.Get(define.RequestTimeoutHeader))
+		Expect(err).To(BeNil())
+		Expect(timeout).To(BeNumerically(">", 0))
+		Expect(timeout).To(BeNumerically("<=", 1000))
+	})
+
+	It("should set the header by the time left on each attempt", func() {
+		var timeouts []int
+		roundTrip := internal.NewRequestTimeoutMiddleware(time.Second)(func(r *http.Request) (*http.Response, error) {
+			timeout, err := strconv.Atoi(r.Header.Get(define.RequestTimeoutHeader))
+			Expect(err).To(BeNil())
+			timeouts = append(timeouts, timeout)
+
+			return nil, nil
+		})
+
+		_, _ = roundTrip(request)
+		time.Sleep(20 * time.Millisecond)
+		_, _ = roundTrip(request)
+
+		Expect(timeouts).To(HaveLen(2))
+		Expect(timeouts[0]).To(BeNumerically("<=", 1000))
+		Expect(timeouts[1]).To(BeNumerically("<=", timeouts[0]-20))
+		Expect(request.Header.Get(define.RequestTimeoutHeader)).To(BeEmpty())
+	})
+
+	It("should not overwrite the existing header", func() {
+		request.Header.Set(define.RequestTimeoutHeader, "100")
+		internal.SetRequestTimeoutHeader(request, time.Second)
+
+		Expect(request.Header.Get(define.RequestTimeoutHeader)).To(Equal("100"))
+	})
+})
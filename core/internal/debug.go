@@ -0,0 +1,199 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/TencentBlueKing/gopkg/logging"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+// DefaultDumpBodyLimit is the default maximum bytes of the body to dump.
+const DefaultDumpBodyLimit = 4096
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// MaskQuery returns a copy of the query with the sensitive values masked.
+func MaskQuery(query url.Values) url.Values {
+	masked := make(url.Values, len(query))
+	for key, values := range query {
+		masked[key] = values
+	}
+
+	for _, key := range sensitiveAuthorizationParams {
+		if values, ok := masked[key]; ok {
+			maskedValues := make([]string, len(values))
+			for i := range values {
+				maskedValues[i] = MaskedValue
+			}
+			masked[key] = maskedValues
+		}
+	}
+
+	return masked
+}
+
+// WireDumper dumps the requests and responses with the secrets masked.
+type WireDumper struct {
+	writer    io.Writer
+	logger    logging.Logger
+	bodyLimit int
+	lock      sync.Mutex
+}
+
+func (d *WireDumper) writeBody(buffer *bytes.Buffer, body []byte, truncated bool) {
+	if len(body) == 0 {
+		return
+	}
+
+	buffer.WriteString("\r\n")
+	buffer.Write(body)
+	if truncated {
+		buffer.WriteString("\r\n... (truncated)")
+	}
+}
+
+func (d *WireDumper) dumpRequest(request *http.Request) (string, error) {
+	body, err := ReadRequestBody(request)
+	if err != nil {
+		return "", err
+	}
+
+	truncated := len(body) > d.bodyLimit
+	if truncated {
+		body = body[:d.bodyLimit]
+	}
+
+	requestUrl := *request.URL
+	requestUrl.RawQuery = MaskQuery(request.URL.Query()).Encode()
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "%s %s HTTP/1.1\r\n", request.Method, requestUrl.RequestURI())
+
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+	fmt.Fprintf(&buffer, "Host: %s\r\n", host)
+
+	err = MaskHeader(request.Header).Write(&buffer)
+	if err != nil {
+		return "", err
+	}
+
+	d.writeBody(&buffer, body, truncated)
+
+	return buffer.String(), nil
+}
+
+func (d *WireDumper) dumpResponse(response *http.Response) (string, error) {
+	var body []byte
+	if response.Body != nil && response.Body != http.NoBody {
+		// read one more byte to know whether the body is truncated, and keep the body readable afterwards
+		prefix, err := io.ReadAll(io.LimitReader(response.Body, int64(d.bodyLimit)+1))
+		if err != nil {
+			return "", err
+		}
+
+		response.Body = readCloser{
+			Reader: io.MultiReader(bytes.NewReader(prefix), response.Body),
+			Closer: response.Body,
+		}
+		body = prefix
+	}
+
+	truncated := len(body) > d.bodyLimit
+	if truncated {
+		body = body[:d.bodyLimit]
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "%s %s\r\n", response.Proto, response.Status)
+
+	err := MaskHeader(response.Header).Write(&buffer)
+	if err != nil {
+		return "", err
+	}
+
+	d.writeBody(&buffer, body, truncated)
+
+	return buffer.String(), nil
+}
+
+func (d *WireDumper) output(title, content string) {
+	if d.writer == nil {
+		if d.logger != nil {
+			d.logger.Info(title, map[string]interface{}{"dump": content})
+		}
+		return
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	_, _ = fmt.Fprintf(d.writer, "%s %s\r\n%s\r\n\r\n", time.Now().Format(time.RFC3339Nano), title, content)
+}
+
+// Middleware dumps the traffic of the requests.
+func (d *WireDumper) Middleware(next define.RoundTrip) define.RoundTrip {
+	return func(request *http.Request) (*http.Response, error) {
+		metadata, _ := define.OperationMetadataFromContext(request.Context())
+
+		dump, err := d.dumpRequest(request)
+		if err != nil {
+			dump = fmt.Sprintf("failed to dump request: %v", err)
+		}
+		d.output(fmt.Sprintf(">>> bkapi request %s", metadata.FullName), dump)
+
+		startedAt := time.Now()
+		response, err := next(request)
+		elapsed := time.Since(startedAt)
+
+		if err != nil {
+			d.output(fmt.Sprintf("<<< bkapi error %s (%s)", metadata.FullName, elapsed), err.Error())
+			return response, err
+		}
+
+		dump, dumpErr := d.dumpResponse(response)
+		if dumpErr != nil {
+			dump = fmt.Sprintf("failed to dump response: %v", dumpErr)
+		}
+		d.output(fmt.Sprintf("<<< bkapi response %s (%s)", metadata.FullName, elapsed), dump)
+
+		return response, nil
+	}
+}
+
+// NewWireDumper creates a WireDumper which writes to the writer, or the logger when the writer is nil.
+// Zero or negative bodyLimit means DefaultDumpBodyLimit.
+func NewWireDumper(writer io.Writer, logger logging.Logger, bodyLimit int) *WireDumper {
+	if bodyLimit <= 0 {
+		bodyLimit = DefaultDumpBodyLimit
+	}
+
+	return &WireDumper{
+		writer:    writer,
+		logger:    logger,
+		bodyLimit: bodyLimit,
+	}
+}
@@ -0,0 +1,93 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+var _ = Describe("WireDumper", func() {
+	var (
+		output    *bytes.Buffer
+		response  *http.Response
+		roundTrip define.RoundTrip
+	)
+
+	BeforeEach(func() {
+		output = &bytes.Buffer{}
+		response = &http.Response{
+			Proto:      "HTTP/1.1",
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Set-Cookie": []string{"bk_token=token"}},
+			Body:       ioutil.NopCloser(strings.NewReader("0123456789")),
+		}
+
+		roundTrip = internal.NewWireDumper(output, nil, 5).Middleware(func(*http.Request) (*http.Response, error) {
+			return response, nil
+		})
+	})
+
+	It("should dump the traffic with the secrets masked", func() {
+		request, err := http.NewRequest(
+			"POST", "http://example.com/hello?bk_app_secret=s3cr3t&name=world", strings.NewReader("abcdefghij"),
+		)
+		Expect(err).To(BeNil())
+		request.Header.Set("X-Bkapi-Authorization", `{"bk_app_code":"app","bk_app_secret":"s3cr3t"}`)
+		request = request.WithContext(define.WithOperationMetadata(request.Context(), define.OperationMetadata{
+			FullName: "testing.api.hello",
+		}))
+
+		result, err := roundTrip(request)
+		Expect(err).To(BeNil())
+
+		dump := output.String()
+		Expect(dump).To(ContainSubstring(">>> bkapi request testing.api.hello"))
+		Expect(dump).To(ContainSubstring("POST /hello?bk_app_secret=%2A%2A%2A%2A%2A%2A&name=world HTTP/1.1"))
+		Expect(dump).To(ContainSubstring("Host: example.com"))
+		Expect(dump).To(ContainSubstring(`"bk_app_code":"app"`))
+		Expect(dump).NotTo(ContainSubstring("s3cr3t"))
+		Expect(dump).NotTo(ContainSubstring("bk_token=token"))
+		Expect(dump).To(ContainSubstring("abcde\r\n... (truncated)"))
+		Expect(dump).To(ContainSubstring("<<< bkapi response testing.api.hello"))
+		Expect(dump).To(ContainSubstring("HTTP/1.1 200 OK"))
+		Expect(dump).To(ContainSubstring("01234\r\n... (truncated)"))
+
+		// the bodies are still readable
+		body, err := ioutil.ReadAll(request.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(Equal("abcdefghij"))
+
+		body, err = ioutil.ReadAll(result.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(Equal("0123456789"))
+	})
+
+	It("should mask the sensitive query params", func() {
+		masked := internal.MaskQuery(map[string][]string{
+			"access_token": {"token"},
+			"name":         {"world"},
+		})
+
+		Expect(masked.Get("access_token")).To(Equal(internal.MaskedValue))
+		Expect(masked.Get("name")).To(Equal("world"))
+	})
+})
@@ -0,0 +1,191 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/TencentBlueKing/gopkg/logging"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+// HttpBkApiClient is a lean client which sends the requests by net/http directly.
+type HttpBkApiClient struct {
+	name             string
+	logger           logging.Logger
+	baseUrl          *url.URL
+	headers          http.Header
+	client           *http.Client
+	pool             *ConnectionPool
+	requestHooks     []RequestHook
+	operationOptions []define.OperationOption
+}
+
+// Name returns the client name.
+func (cli *HttpBkApiClient) Name() string {
+	return cli.name
+}
+
+// Logger returns the client logger.
+func (cli *HttpBkApiClient) Logger() logging.Logger {
+	return cli.logger
+}
+
+// SetLogger replaces the client logger, nil disables the logging.
+func (cli *HttpBkApiClient) SetLogger(logger logging.Logger) {
+	cli.logger = logger
+}
+
+// Apply method applies the given options to the client.
+func (cli *HttpBkApiClient) Apply(opts ...define.BkApiClientOption) error {
+	for _, opt := range opts {
+		err := opt.ApplyToClient(cli)
+		if err != nil {
+			return define.ErrorWrapf(
+				err, "failed to apply option %v to client %s", opt, cli.Name(),
+			)
+		}
+	}
+
+	return nil
+}
+
+// AddOperationOptions method adds the common options to each operation.
+func (cli *HttpBkApiClient) AddOperationOptions(opts ...define.OperationOption) error {
+	cli.operationOptions = append(cli.operationOptions, opts...)
+	return nil
+}
+
+// UseConnectionPool makes the client send the requests by the transport of the pool.
+func (cli *HttpBkApiClient) UseConnectionPool(pool *ConnectionPool) {
+	if cli.pool != nil && cli.pool != pool {
+		cli.pool.Release()
+	}
+
+	cli.pool = pool
+	cli.client.Transport = pool.RoundTripper()
+}
+
+// hookTransport configures the transport of the client, the client owns the transport of the pool before that.
+func (cli *HttpBkApiClient) hookTransport(hook TransportHook) error {
+	if cli.pool != nil && cli.client.Transport == cli.pool.RoundTripper() {
+		cli.UseConnectionPool(cli.pool.Own())
+	}
+
+	// if using a custom transport, just ignore it
+	transport, ok := cli.client.Transport.(*http.Transport)
+	if ok {
+		hook(transport)
+	}
+
+	return nil
+}
+
+// ConnectionStats returns the connection reuse statistics of the client.
+func (cli *HttpBkApiClient) ConnectionStats() define.ConnectionStats {
+	if cli.pool == nil {
+		return define.ConnectionStats{}
+	}
+
+	return cli.pool.Stats()
+}
+
+// Close method releases the connection pool of the client, it is safe to call it multiple times.
+// The transport borrowed from the others, such as http.DefaultTransport, is left to them.
+func (cli *HttpBkApiClient) Close() error {
+	if cli.pool != nil {
+		cli.pool.Release()
+	}
+
+	return nil
+}
+
+func (cli *HttpBkApiClient) applyBackendOption(o *BackendOption) error {
+	switch {
+	case o.requestHook != nil:
+		cli.requestHooks = append(cli.requestHooks, o.requestHook)
+	case o.transportHook != nil:
+		return cli.hookTransport(o.transportHook)
+	case o.clientHook != nil:
+		return o.clientHook(cli.client)
+	case len(o.middlewares) > 0, len(o.transportMiddlewares) > 0:
+		return cli.AddOperationOptions(o)
+	}
+
+	return nil
+}
+
+func (cli *HttpBkApiClient) newOperationUrl(config define.OperationConfig) url.URL {
+	operationUrl := *cli.baseUrl
+	path := strings.TrimSuffix(operationUrl.Path, "/")
+	operationUrl.Path = fmt.Sprintf("%s/%s", path, strings.TrimPrefix(config.GetPath(), "/"))
+	operationUrl.RawPath = ""
+
+	return operationUrl
+}
+
+// NewOperation will create a new operation dynamically and apply the given options.
+func (cli *HttpBkApiClient) NewOperation(
+	provider define.OperationConfigProvider,
+	opts ...define.OperationOption,
+) define.Operation {
+	config := provider.ProvideConfig()
+	operation := NewHttpOperation(
+		newOperationName(config), cli, config.GetMethod(), cli.newOperationUrl(config),
+	)
+	operation.path = config.GetPath()
+
+	// the policy should be applied first, so it can be overridden by the options
+	applyOperationPolicy(operation, config)
+
+	for _, o := range [][]define.OperationOption{
+		cli.operationOptions, opts,
+	} {
+		if len(o) > 0 {
+			operation.Apply(o...)
+		}
+	}
+
+	return operation
+}
+
+// NewHttpBkApiClient creates a new HttpBkApiClient.
+func NewHttpBkApiClient(name string, client *http.Client, config define.ClientConfig) (*HttpBkApiClient, error) {
+	baseUrl := config.GetUrl()
+	if baseUrl == "" {
+		return nil, define.ErrorWrapf(define.ErrConfigInvalid, "base url is empty")
+	}
+
+	parsedUrl, err := url.Parse(baseUrl)
+	if err != nil {
+		return nil, define.ErrorWrapf(define.ErrConfigInvalid, "base url %s is invalid: %v", baseUrl, err)
+	}
+
+	headers := make(http.Header)
+	headers.Set("User-Agent", DefaultUserAgent)
+	for key, value := range config.GetAuthorizationHeaders() {
+		headers.Set(key, value)
+	}
+
+	return &HttpBkApiClient{
+		name:             name,
+		logger:           config.GetLogger(),
+		baseUrl:          parsedUrl,
+		headers:          headers,
+		client:           client,
+		operationOptions: make([]define.OperationOption, 0),
+	}, nil
+}
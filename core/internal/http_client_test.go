@@ -0,0 +1,173 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"net/http"
+
+	"github.com/TencentBlueKing/gopkg/logging"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal/mock"
+)
+
+// idleConnectionsCloser counts the calls of CloseIdleConnections.
+type idleConnectionsCloser struct {
+	http.RoundTripper
+	closed int
+}
+
+func (c *idleConnectionsCloser) CloseIdleConnections() {
+	c.closed++
+}
+
+var _ = Describe("HttpClient", func() {
+	var (
+		ctrl            *gomock.Controller
+		mockTransport   *mock.MockRoundTripper
+		clientConfig    *mock.MockClientConfig
+		operationConfig *mock.MockOperationConfig
+		provider        *mock.MockOperationConfigProvider
+		baseUrl         string
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockTransport = mock.NewMockRoundTripper(ctrl)
+		baseUrl = "http://api.example.com/prod/"
+
+		clientConfig = mock.NewMockClientConfig(ctrl)
+		clientConfig.EXPECT().GetUrl().DoAndReturn(func() string { return baseUrl }).AnyTimes()
+		clientConfig.EXPECT().GetAuthorizationHeaders().Return(map[string]string{
+			"X-Bkapi-Authorization": `{"bk_app_code":"app"}`,
+		}).AnyTimes()
+		clientConfig.EXPECT().GetLogger().Return(logging.GetLogger("")).AnyTimes()
+
+		operationConfig = mock.NewMockOperationConfig(ctrl)
+		operationConfig.EXPECT().GetName().Return("testing").AnyTimes()
+		operationConfig.EXPECT().GetMethod().Return("POST").AnyTimes()
+		operationConfig.EXPECT().GetPath().Return("/api/{id}/").AnyTimes()
+
+		provider = mock.NewMockOperationConfigProvider(ctrl)
+		provider.EXPECT().ProvideConfig().Return(operationConfig).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	newClient := func() *internal.HttpBkApiClient {
+		client, err := internal.NewHttpBkApiClient("client", &http.Client{Transport: mockTransport}, clientConfig)
+		Expect(err).To(BeNil())
+
+		return client
+	}
+
+	mockRoundTrip := func() *http.Request {
+		var request http.Request
+		mockTransport.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			request = *req
+			return &http.Response{StatusCode: http.StatusOK, Request: req}, nil
+		})
+
+		return &request
+	}
+
+	It("should return error when config url is missing", func() {
+		baseUrl = ""
+
+		_, err := internal.NewHttpBkApiClient("client", http.DefaultClient, clientConfig)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should send the request with the client headers", func() {
+		client := newClient()
+		request := mockRoundTrip()
+
+		_, err := client.NewOperation(provider).
+			SetPathParams(map[string]string{"id": "1"}).
+			Request()
+		Expect(err).To(BeNil())
+
+		Expect(client.Name()).To(Equal("client"))
+		Expect(request.Method).To(Equal("POST"))
+		Expect(request.URL.String()).To(Equal("http://api.example.com/prod/api/1/"))
+		Expect(request.Header.Get("User-Agent")).To(Equal(internal.DefaultUserAgent))
+		Expect(request.Header.Get("X-Bkapi-Authorization")).To(Equal(`{"bk_app_code":"app"}`))
+	})
+
+	It("should apply the backend options", func() {
+		client := newClient()
+		request := mockRoundTrip()
+
+		var calls []string
+		Expect(client.Apply(
+			internal.NewRequestHookOption(func(request *http.Request) error {
+				request.Header.Set("X-Testing", "testing")
+				return nil
+			}),
+			internal.NewMiddlewareOption(func(next define.RoundTrip) define.RoundTrip {
+				return func(request *http.Request) (*http.Response, error) {
+					calls = append(calls, "client")
+					return next(request)
+				}
+			}),
+		)).To(Succeed())
+
+		_, err := client.NewOperation(provider, internal.NewMiddlewareOption(
+			func(next define.RoundTrip) define.RoundTrip {
+				return func(request *http.Request) (*http.Response, error) {
+					calls = append(calls, "operation")
+					return next(request)
+				}
+			},
+		)).SetPathParams(map[string]string{"id": "1"}).Request()
+		Expect(err).To(BeNil())
+
+		Expect(request.Header.Get("X-Testing")).To(Equal("testing"))
+		Expect(calls).To(Equal([]string{"client", "operation"}))
+	})
+
+	It("should apply the http client hook to the client", func() {
+		client := newClient()
+
+		anotherTransport := mock.NewMockRoundTripper(ctrl)
+		anotherTransport.EXPECT().RoundTrip(gomock.Any()).Return(&http.Response{StatusCode: http.StatusOK}, nil)
+
+		Expect(client.Apply(internal.NewHttpClientHookOption(func(client *http.Client) error {
+			client.Transport = anotherTransport
+			return nil
+		}))).To(Succeed())
+
+		_, err := client.NewOperation(provider).SetPathParams(map[string]string{"id": "1"}).Request()
+		Expect(err).To(BeNil())
+	})
+
+	It("should not close the idle connections of the transport it does not own", func() {
+		transport := &idleConnectionsCloser{RoundTripper: mockTransport}
+		client, err := internal.NewHttpBkApiClient("client", &http.Client{Transport: transport}, clientConfig)
+		Expect(err).To(BeNil())
+
+		Expect(client.Close()).To(Succeed())
+		Expect(transport.closed).To(BeZero())
+	})
+
+	It("should not accept the gentleman plugin option", func() {
+		client := newClient()
+
+		Expect(client.Apply(internal.NewPluginOption())).NotTo(Succeed())
+	})
+})
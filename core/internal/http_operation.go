@@ -0,0 +1,543 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+// HttpOperation is a lean operation which builds the request by net/http directly.
+type HttpOperation struct {
+	name             string
+	path             string
+	method           string
+	url              url.URL
+	idempotencyKey   string
+	idempotent       bool
+	retryPolicy      *define.RetryPolicy
+	err              error
+	ctx              context.Context
+	header           http.Header
+	query            map[string]string
+	body             io.Reader
+	contentLength    int64
+	hasContentLength bool
+	file             *MultipartFile
+	bodyData         interface{}
+	bodyProvider     define.BodyProvider
+	result           interface{}
+	resultProvider   define.ResultProvider
+	requestHooks     []RequestHook
+	clientHooks      []HttpClientHook
+	middlewares      []define.Middleware
+	// transportMiddlewares are inside the middlewares, so they see the final requests
+	transportMiddlewares []define.Middleware
+	client               *HttpBkApiClient
+}
+
+// Name returns the operation name.
+func (op *HttpOperation) Name() string {
+	return op.name
+}
+
+// ClientName returns the client name.
+func (op *HttpOperation) ClientName() string {
+	return op.client.Name()
+}
+
+// FullName returns the operation name.
+func (op *HttpOperation) FullName() string {
+	// <client>.<group>.<resource>
+	return fmt.Sprintf("%s.api.%s", op.ClientName(), op.name)
+}
+
+// String returns the operation name.
+func (op *HttpOperation) String() string {
+	return fmt.Sprintf("%s %s", op.ClientName(), op.name)
+}
+
+// LogValue makes the operation logged by its full name in slog.
+func (op *HttpOperation) LogValue() slog.Value {
+	return slog.StringValue(op.FullName())
+}
+
+// GetError returns the operation error.
+func (op *HttpOperation) GetError() error {
+	return op.err
+}
+
+// Apply method applies the given options to the operation.
+func (op *HttpOperation) Apply(opts ...define.OperationOption) define.Operation {
+	for _, opt := range opts {
+		err := opt.ApplyToOperation(op)
+		if err != nil {
+			op.err = define.ErrorWrapf(err, "failed to apply option %s", opt)
+		}
+	}
+
+	return op
+}
+
+func (op *HttpOperation) applyBackendOption(o *BackendOption) error {
+	switch {
+	case o.requestHook != nil:
+		op.requestHooks = append(op.requestHooks, o.requestHook)
+	case o.clientHook != nil:
+		op.clientHooks = append(op.clientHooks, o.clientHook)
+	default:
+		op.UseMiddlewares(o.middlewares...)
+		op.UseTransportMiddlewares(o.transportMiddlewares...)
+	}
+
+	return nil
+}
+
+// SetHeaders used to set the request headers.
+func (op *HttpOperation) SetHeaders(headers map[string]string) define.Operation {
+	for key, value := range headers {
+		op.header.Set(key, value)
+	}
+
+	return op
+}
+
+// SetQueryParams used to set the request query parameters.
+func (op *HttpOperation) SetQueryParams(params map[string]string) define.Operation {
+	if op.query == nil {
+		op.query = make(map[string]string, len(params))
+	}
+
+	for key, value := range params {
+		op.query[key] = value
+	}
+
+	return op
+}
+
+// SetPathParams used to set the request path parameters.
+func (op *HttpOperation) SetPathParams(params map[string]string) define.Operation {
+	ReplacePathPlaceHolder(&op.url, params)
+
+	return op
+}
+
+// SetBodyReader used to set the operation body.
+func (op *HttpOperation) SetBodyReader(body io.Reader) define.Operation {
+	op.body = body
+
+	return op
+}
+
+// SetBody used to set the operation body.
+func (op *HttpOperation) SetBody(body interface{}) define.Operation {
+	op.bodyData = body
+
+	return op
+}
+
+// SetBodyProvider used to set the operation body provider.
+func (op *HttpOperation) SetBodyProvider(bodyProvider define.BodyProvider) define.Operation {
+	op.bodyProvider = bodyProvider
+
+	return op
+}
+
+// SetResult used to set the operation result.
+func (op *HttpOperation) SetResult(result interface{}) define.Operation {
+	op.result = result
+
+	return op
+}
+
+// SetResultProvider used to set the operation result provider.
+func (op *HttpOperation) SetResultProvider(provider define.ResultProvider) define.Operation {
+	op.resultProvider = provider
+
+	return op
+}
+
+// SetContext used to set the request context, nil means context.Background().
+func (op *HttpOperation) SetContext(ctx context.Context) define.Operation {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	op.ctx = ctx
+
+	return op
+}
+
+// SetContentType used to set the request content type.
+func (op *HttpOperation) SetContentType(contentType string) define.Operation {
+	op.header.Set("Content-Type", contentType)
+
+	return op
+}
+
+// SetContentLength used to set the request content length.
+func (op *HttpOperation) SetContentLength(length int64) define.Operation {
+	op.contentLength = length
+	op.hasContentLength = true
+
+	return op
+}
+
+// SetFile sends the file as a multipart form.
+func (op *HttpOperation) SetFile(name string, file *os.File) define.Operation {
+	op.file = &MultipartFile{Name: name, Reader: file}
+
+	return op
+}
+
+// SetIdempotencyKey sets the idempotency key of the operation and sends it as a header.
+func (op *HttpOperation) SetIdempotencyKey(key string) define.Operation {
+	op.idempotencyKey = key
+	op.header.Set(define.IdempotencyKeyHeader, key)
+
+	return op
+}
+
+// IdempotencyKey returns the idempotency key of the operation.
+func (op *HttpOperation) IdempotencyKey() string {
+	return op.idempotencyKey
+}
+
+// SetTimeout sets the timeout of the operation.
+func (op *HttpOperation) SetTimeout(duration time.Duration) define.Operation {
+	op.clientHooks = append(op.clientHooks, func(client *http.Client) error {
+		client.Timeout = duration
+		return nil
+	})
+
+	return op
+}
+
+// SetIdempotent marks whether the operation is idempotent.
+func (op *HttpOperation) SetIdempotent(idempotent bool) define.Operation {
+	op.idempotent = idempotent
+
+	return op
+}
+
+// SetRetryPolicy sets the retry policy of the operation,
+// only the idempotent operations or the operations with an idempotency key will be retried.
+func (op *HttpOperation) SetRetryPolicy(policy *define.RetryPolicy) define.Operation {
+	op.retryPolicy = policy
+
+	return op
+}
+
+// Metadata returns the metadata of the operation.
+func (op *HttpOperation) Metadata() define.OperationMetadata {
+	return define.OperationMetadata{
+		ClientName: op.ClientName(),
+		Name:       op.name,
+		FullName:   op.FullName(),
+		Path:       op.path,
+	}
+}
+
+// UseMiddlewares appends the middlewares to wrap the request execution.
+func (op *HttpOperation) UseMiddlewares(middlewares ...define.Middleware) {
+	op.middlewares = append(op.middlewares, middlewares...)
+}
+
+// UseTransportMiddlewares appends the middlewares to wrap the transport, inside all the other middlewares.
+func (op *HttpOperation) UseTransportMiddlewares(middlewares ...define.Middleware) {
+	op.transportMiddlewares = append(op.transportMiddlewares, middlewares...)
+}
+
+// httpClient returns the http client of the client directly when the operation has nothing to customize,
+// otherwise a copy is returned. Note that the transport is still shared with the client.
+func (op *HttpOperation) httpClient() (*http.Client, error) {
+	retryable := isRetryable(op.retryPolicy, op.idempotent, op.idempotencyKey)
+	middlewares := joinMiddlewares(op.middlewares, op.transportMiddlewares)
+	if len(op.clientHooks) == 0 && len(middlewares) == 0 && !retryable {
+		return op.client.client, nil
+	}
+
+	client := *op.client.client
+	for _, hook := range op.clientHooks {
+		err := hook(&client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the retry should be the outermost, so the middlewares can observe each attempt
+	if len(middlewares) > 0 {
+		client.Transport = WrapOperationTransport(client.Transport, op.Metadata(), middlewares)
+	}
+	if retryable {
+		// the time budget is computed for each attempt, so the retries advertise the time left
+		client.Transport = WrapTransport(client.Transport, []define.Middleware{NewRequestTimeoutMiddleware(client.Timeout)})
+		client.Transport = NewRetryRoundTripper(client.Transport, *op.retryPolicy)
+	}
+
+	return &client, nil
+}
+
+func (op *HttpOperation) callBodyProvider() error {
+	if op.bodyProvider == nil {
+		return nil
+	}
+
+	err := op.bodyProvider.ProvideBody(op, op.bodyData)
+	if err != nil {
+		return define.ErrorWrapf(err, "failed to set body for operation %s", op)
+	}
+
+	return nil
+}
+
+func (op *HttpOperation) setMultipartFile(request *http.Request) error {
+	body, contentType, err := NewMultipartBody(nil, *op.file)
+	if err != nil {
+		return err
+	}
+
+	if request.Method == http.MethodGet {
+		request.Method = http.MethodPost
+	}
+
+	request.Header.Add("Content-Type", contentType)
+	request.Body = ioutil.NopCloser(body)
+	request.ContentLength = int64(body.Len())
+
+	return nil
+}
+
+func (op *HttpOperation) runRequestHooks(request *http.Request) error {
+	for _, hooks := range [][]RequestHook{op.client.requestHooks, op.requestHooks} {
+		for _, hook := range hooks {
+			err := hook(request)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (op *HttpOperation) newRequest(client *http.Client) (*http.Request, error) {
+	// when the operation already has an error, return it directly
+	if op.err != nil {
+		return nil, op.err
+	}
+
+	err := op.callBodyProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	// the url has been parsed, so it is assigned directly to avoid parsing again
+	request, err := http.NewRequestWithContext(op.ctx, op.method, "", op.body)
+	if err != nil {
+		return nil, define.ErrorWrapf(err, "failed to create request for operation %s", op)
+	}
+
+	requestUrl := op.url
+	request.URL = &requestUrl
+	request.Host = requestUrl.Host
+
+	request.Header = op.client.headers.Clone()
+	for key, values := range op.header {
+		request.Header[key] = values
+	}
+
+	if len(op.query) > 0 {
+		query := request.URL.Query()
+		for key, value := range op.query {
+			query.Set(key, value)
+		}
+		request.URL.RawQuery = query.Encode()
+	}
+
+	if op.hasContentLength {
+		request.ContentLength = op.contentLength
+	}
+
+	if op.file != nil {
+		err = op.setMultipartFile(request)
+		if err != nil {
+			return nil, define.ErrorWrapf(err, "failed to set file for operation %s", op)
+		}
+	}
+
+	err = op.runRequestHooks(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// all the request hooks have been run, so the path should be completed
+	keys := FindPlaceHolders(GetRawPath(request.URL))
+	if len(keys) > 0 {
+		return nil, &define.MissingPathParamError{Keys: keys}
+	}
+
+	// the retries set the time budget on each attempt
+	if !isRetryable(op.retryPolicy, op.idempotent, op.idempotencyKey) {
+		SetRequestTimeoutHeader(request, client.Timeout)
+	}
+
+	return request, nil
+}
+
+func (op *HttpOperation) callResultProvider(response *http.Response) error {
+	// it should read the response body to avoid the resource leak
+	var body []byte
+	if response.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return define.ErrorWrapf(err, "failed to read response body for operation %s", op)
+		}
+	}
+
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	response.Close = true
+
+	if op.resultProvider == nil {
+		return nil
+	}
+
+	err := op.resultProvider.ProvideResult(response, op.result)
+	if err != nil {
+		return define.ErrorWrapf(err, "failed to decode result for operation %s", op)
+	}
+
+	return nil
+}
+
+func (op *HttpOperation) checkBkapiError(response *http.Response) error {
+	// keep the same behavior as gentleman, 2xx and 3xx are both ok
+	statusRange := response.StatusCode / 100
+	if statusRange >= 2 && statusRange <= 3 {
+		return nil
+	}
+
+	detail := NewBkApiResponseDetailFromResponse(response)
+	err := detail.GetError()
+	if err != nil && response.Body != nil {
+		_, _ = io.Copy(ioutil.Discard, response.Body)
+		response.Body.Close()
+	}
+
+	return err
+}
+
+func (op *HttpOperation) send() (*http.Response, error) {
+	client, err := op.httpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := op.newRequest(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if op.client.pool != nil {
+		request = op.client.pool.TraceRequest(request)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// a custom transport may not set the request of the response
+	if response.Request == nil {
+		response.Request = request
+	}
+
+	logOperationResponse(op.client.logger, op, response)
+
+	err = op.checkBkapiError(response)
+	if err != nil {
+		return nil, err
+	}
+
+	err = op.callResultProvider(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Request will send the operation request and return the response.
+func (op *HttpOperation) Request() (*http.Response, error) {
+	response, err := op.send()
+	if err != nil && op.idempotencyKey != "" {
+		return response, NewIdempotencyKeyError(err, op.idempotencyKey)
+	}
+
+	return response, err
+}
+
+func (op *HttpOperation) build() (*http.Request, error) {
+	client, err := op.httpClient()
+	if err != nil {
+		return nil, define.ErrorWrapf(err, "failed to build operation %s", op)
+	}
+
+	request, err := op.newRequest(client)
+	if err != nil {
+		return nil, define.ErrorWrapf(err, "failed to build operation %s", op)
+	}
+
+	return request, nil
+}
+
+// Build will run all the request hooks and body providers, and return the final request without sending it.
+func (op *HttpOperation) Build(ctx context.Context) (*http.Request, error) {
+	if ctx != nil {
+		op.SetContext(ctx)
+	}
+
+	return op.build()
+}
+
+// Curl will build the operation and render the request as a curl command with the secrets masked.
+func (op *HttpOperation) Curl() (string, error) {
+	request, err := op.build()
+	if err != nil {
+		return "", err
+	}
+
+	return NewCurlCommand(request, true)
+}
+
+// NewHttpOperation creates a new HttpOperation.
+func NewHttpOperation(name string, client *HttpBkApiClient, method string, operationUrl url.URL) *HttpOperation {
+	return &HttpOperation{
+		name:   name,
+		method: method,
+		url:    operationUrl,
+		ctx:    context.Background(),
+		header: make(http.Header),
+		client: client,
+	}
+}
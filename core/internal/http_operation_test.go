@@ -0,0 +1,278 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal/mock"
+)
+
+var _ = Describe("HttpOperation", func() {
+	var (
+		ctrl          *gomock.Controller
+		mockTransport *mock.MockRoundTripper
+		response      *http.Response
+		operation     *internal.HttpOperation
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockTransport = mock.NewMockRoundTripper(ctrl)
+		response = &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+
+		clientConfig := mock.NewMockClientConfig(ctrl)
+		clientConfig.EXPECT().GetUrl().Return("http://api.example.com/").AnyTimes()
+		clientConfig.EXPECT().GetAuthorizationHeaders().Return(map[string]string{
+			"X-Bkapi-Authorization": `{"access_token":"token"}`,
+		}).AnyTimes()
+		clientConfig.EXPECT().GetLogger().Return(nil).AnyTimes()
+
+		client, err := internal.NewHttpBkApiClient("client", &http.Client{Transport: mockTransport}, clientConfig)
+		Expect(err).To(BeNil())
+
+		operationUrl, err := url.Parse("http://api.example.com/hello/{name}")
+		Expect(err).To(BeNil())
+
+		operation = internal.NewHttpOperation("test", client, "GET", *operationUrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	mockTransportRoundTrip := func() {
+		mockTransport.EXPECT().
+			RoundTrip(gomock.Any()).
+			DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				response.Request = req
+				return response, nil
+			})
+	}
+
+	It("should return correct names", func() {
+		Expect(operation.Name()).To(Equal("test"))
+		Expect(operation.FullName()).To(Equal("client.api.test"))
+		Expect(fmt.Sprintf("%v", operation)).To(Equal("client test"))
+	})
+
+	It("should set the request", func() {
+		mockTransportRoundTrip()
+		ctx := context.WithValue(context.Background(), "key", "testing")
+
+		response, err := operation.
+			SetContext(ctx).
+			SetPathParams(map[string]string{"name": "a/b"}).
+			SetQueryParams(map[string]string{"foo": "bar"}).
+			SetHeaders(map[string]string{"X-Testing": "testing"}).
+			SetContentType("text/plain").
+			SetBodyReader(strings.NewReader("testing")).
+			Request()
+		Expect(err).To(BeNil())
+
+		request := response.Request
+		Expect(request.Context().Value("key")).To(Equal("testing"))
+		Expect(request.URL.EscapedPath()).To(Equal("/hello/a%2Fb"))
+		Expect(request.URL.Query().Get("foo")).To(Equal("bar"))
+		Expect(request.Header.Get("X-Testing")).To(Equal("testing"))
+		Expect(request.Header.Get("Content-Type")).To(Equal("text/plain"))
+		Expect(request.ContentLength).To(Equal(int64(7)))
+
+		body, err := ioutil.ReadAll(request.Body)
+		Expect(err).To(BeNil())
+		Expect(string(body)).To(Equal("testing"))
+	})
+
+	It("should fail when path params are missing", func() {
+		_, err := operation.Request()
+
+		var missingErr *define.MissingPathParamError
+		Expect(errors.As(err, &missingErr)).To(BeTrue())
+		Expect(missingErr.Keys).To(Equal([]string{"name"}))
+	})
+
+	It("should fail on apply", func() {
+		option := mock.NewMockOperationOption(ctrl)
+		option.EXPECT().ApplyToOperation(gomock.Any()).Return(fmt.Errorf("testing"))
+
+		_, err := operation.Apply(option).Request()
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should fail on roundtrip", func() {
+		mockTransport.EXPECT().RoundTrip(gomock.Any()).Return(nil, fmt.Errorf("testing"))
+
+		response, err := operation.SetPathParams(map[string]string{"name": "world"}).Request()
+		Expect(err).NotTo(BeNil())
+		Expect(response).To(BeNil())
+	})
+
+	It("should provide the body and decode the result", func() {
+		mockTransportRoundTrip()
+		response.Body = ioutil.NopCloser(strings.NewReader(`{"foo":"bar"}`))
+
+		bodyProvider := mock.NewMockBodyProvider(ctrl)
+		bodyProvider.EXPECT().ProvideBody(operation, "data").DoAndReturn(
+			func(op define.Operation, data interface{}) error {
+				op.SetContentType("application/json").SetBodyReader(strings.NewReader(`"data"`))
+				return nil
+			},
+		)
+
+		resultProvider := mock.NewMockResultProvider(ctrl)
+		resultProvider.EXPECT().ProvideResult(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(response *http.Response, result interface{}) error {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).To(BeNil())
+				Expect(string(body)).To(Equal(`{"foo":"bar"}`))
+				return nil
+			},
+		)
+
+		_, err := operation.
+			SetPathParams(map[string]string{"name": "world"}).
+			SetBodyProvider(bodyProvider).
+			SetBody("data").
+			SetResultProvider(resultProvider).
+			Request()
+		Expect(err).To(BeNil())
+		Expect(response.Request.Header.Get("Content-Type")).To(Equal("application/json"))
+	})
+
+	It("should send the file as multipart form", func() {
+		mockTransportRoundTrip()
+
+		path := filepath.Join(GinkgoT().TempDir(), "testing.txt")
+		Expect(ioutil.WriteFile(path, []byte("content"), 0o600)).To(Succeed())
+		file, err := os.Open(path)
+		Expect(err).To(BeNil())
+		defer file.Close()
+
+		response, err := operation.
+			SetPathParams(map[string]string{"name": "world"}).
+			SetFile("testing.txt", file).
+			Request()
+		Expect(err).To(BeNil())
+
+		request := response.Request
+		Expect(request.Method).To(Equal("POST"))
+		Expect(request.ParseMultipartForm(1024)).To(Succeed())
+		Expect(request.MultipartForm.File).To(HaveKey("testing.txt"))
+	})
+
+	It("should return bkapi error", func() {
+		response.StatusCode = 403
+		response.Header = http.Header{
+			"X-Bkapi-Request-Id":    []string{"request-id"},
+			"X-Bkapi-Error-Code":    []string{"error-code"},
+			"X-Bkapi-Error-Message": []string{"error-message"},
+		}
+		mockTransportRoundTrip()
+
+		_, err := operation.SetPathParams(map[string]string{"name": "world"}).Request()
+		Expect(err).NotTo(BeNil())
+
+		detail, ok := err.(*internal.BkApiResponseDetail)
+		Expect(ok).To(BeTrue())
+		Expect(detail.ErrorCode()).To(Equal("error-code"))
+	})
+
+	It("should build the request without sending", func() {
+		request, err := operation.
+			SetPathParams(map[string]string{"name": "world"}).
+			Build(context.Background())
+		Expect(err).To(BeNil())
+
+		Expect(request.URL.String()).To(Equal("http://api.example.com/hello/world"))
+		Expect(request.Header.Get("X-Bkapi-Authorization")).To(Equal(`{"access_token":"token"}`))
+	})
+
+	It("should render the request as curl command", func() {
+		command, err := operation.SetPathParams(map[string]string{"name": "world"}).Curl()
+		Expect(err).To(BeNil())
+
+		Expect(command).To(ContainSubstring("'http://api.example.com/hello/world'"))
+		Expect(command).NotTo(ContainSubstring(`"token"`))
+	})
+
+	It("should propagate the timeout by header", func() {
+		mockTransportRoundTrip()
+
+		operation.SetTimeout(time.Minute)
+		response, err := operation.SetPathParams(map[string]string{"name": "world"}).Request()
+		Expect(err).To(BeNil())
+
+		Expect(response.Request.Header.Get(define.RequestTimeoutHeader)).NotTo(BeEmpty())
+	})
+
+	It("should retry the idempotent operation", func() {
+		mockTransport.EXPECT().RoundTrip(gomock.Any()).Return(&http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil)
+		mockTransportRoundTrip()
+
+		operation.SetIdempotent(true)
+		operation.SetRetryPolicy(&define.RetryPolicy{MaxAttempts: 3})
+
+		response, err := operation.SetPathParams(map[string]string{"name": "world"}).Request()
+		Expect(err).To(BeNil())
+		Expect(internal.GetRetryAttempt(response.Request.Context())).To(Equal(2))
+	})
+
+	It("should expose the idempotency key by the error", func() {
+		mockTransport.EXPECT().RoundTrip(gomock.Any()).Return(nil, fmt.Errorf("testing"))
+
+		operation.SetIdempotencyKey("key")
+		_, err := operation.SetPathParams(map[string]string{"name": "world"}).Request()
+
+		var keyErr define.IdempotencyKeyError
+		Expect(errors.As(err, &keyErr)).To(BeTrue())
+		Expect(keyErr.IdempotencyKey()).To(Equal("key"))
+	})
+
+	It("should wrap the request by middlewares", func() {
+		mockTransportRoundTrip()
+
+		var calls []string
+		newMiddleware := func(name string) define.Middleware {
+			return func(next define.RoundTrip) define.RoundTrip {
+				return func(request *http.Request) (*http.Response, error) {
+					calls = append(calls, name)
+					return next(request)
+				}
+			}
+		}
+
+		_, err := operation.
+			Apply(internal.NewMiddlewareOption(newMiddleware("a"), newMiddleware("b"))).
+			SetPathParams(map[string]string{"name": "world"}).
+			Request()
+		Expect(err).To(BeNil())
+		Expect(calls).To(Equal([]string{"a", "b"}))
+	})
+})
@@ -0,0 +1,68 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+// IdempotencyKeyError wraps the error of an operation with its idempotency key.
+type IdempotencyKeyError struct {
+	err            error
+	idempotencyKey string
+}
+
+// Error renders the error message.
+func (e *IdempotencyKeyError) Error() string {
+	return fmt.Sprintf("%s, idempotencyKey: %s", e.err, e.idempotencyKey)
+}
+
+// Cause returns the wrapped error.
+func (e *IdempotencyKeyError) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the wrapped error.
+func (e *IdempotencyKeyError) Unwrap() error {
+	return e.err
+}
+
+// IdempotencyKey returns the idempotency key of the operation.
+func (e *IdempotencyKeyError) IdempotencyKey() string {
+	return e.idempotencyKey
+}
+
+// NewIdempotencyKeyError creates a new IdempotencyKeyError.
+func NewIdempotencyKeyError(err error, idempotencyKey string) *IdempotencyKeyError {
+	return &IdempotencyKeyError{
+		err:            err,
+		idempotencyKey: idempotencyKey,
+	}
+}
+
+// NewIdempotencyKey generates a random idempotency key in uuid v4 format.
+func NewIdempotencyKey() (string, error) {
+	var uuid [16]byte
+
+	_, err := rand.Read(uuid[:])
+	if err != nil {
+		return "", define.ErrorWrapf(err, "failed to generate idempotency key")
+	}
+
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // version 4
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:]), nil
+}
@@ -0,0 +1,43 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pkgErrors "github.com/pkg/errors"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+var _ = Describe("Idempotency", func() {
+	It("should generate different keys", func() {
+		key1, err := internal.NewIdempotencyKey()
+		Expect(err).To(BeNil())
+		Expect(key1).To(MatchRegexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`))
+
+		key2, err := internal.NewIdempotencyKey()
+		Expect(err).To(BeNil())
+		Expect(key2).NotTo(Equal(key1))
+	})
+
+	It("should wrap the error with idempotency key", func() {
+		err := internal.NewIdempotencyKeyError(define.ErrBkApiRequest, "testing")
+
+		Expect(err.IdempotencyKey()).To(Equal("testing"))
+		Expect(errors.Is(err, define.ErrBkApiRequest)).To(BeTrue())
+		Expect(pkgErrors.Cause(err)).To(Equal(define.ErrBkApiRequest))
+	})
+})
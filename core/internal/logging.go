@@ -0,0 +1,106 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+// ContextFieldsExtractor extracts the log fields from the context, such as the trace ids.
+type ContextFieldsExtractor func(ctx context.Context) map[string]interface{}
+
+type contextFieldsExtractorEntry struct {
+	id        uint64
+	extractor ContextFieldsExtractor
+}
+
+var (
+	contextFieldsExtractorsLock   sync.RWMutex
+	contextFieldsExtractorsNextId uint64
+	contextFieldsExtractors       []contextFieldsExtractorEntry
+)
+
+// RegisterContextFieldsExtractor registers an extractor to add the log fields from the context,
+// the returned function unregisters the extractor.
+func RegisterContextFieldsExtractor(extractor ContextFieldsExtractor) (unregister func()) {
+	contextFieldsExtractorsLock.Lock()
+	defer contextFieldsExtractorsLock.Unlock()
+
+	contextFieldsExtractorsNextId++
+	id := contextFieldsExtractorsNextId
+	contextFieldsExtractors = append(contextFieldsExtractors, contextFieldsExtractorEntry{id: id, extractor: extractor})
+
+	return func() {
+		contextFieldsExtractorsLock.Lock()
+		defer contextFieldsExtractorsLock.Unlock()
+
+		for i, entry := range contextFieldsExtractors {
+			if entry.id == id {
+				contextFieldsExtractors = append(contextFieldsExtractors[:i:i], contextFieldsExtractors[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// ResetContextFieldsExtractors removes all the registered extractors.
+func ResetContextFieldsExtractors() {
+	contextFieldsExtractorsLock.Lock()
+	defer contextFieldsExtractorsLock.Unlock()
+
+	contextFieldsExtractors = nil
+}
+
+// ContextFields returns the log fields of the context, including the operation metadata
+// and the fields of the registered extractors.
+func ContextFields(ctx context.Context) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if ctx == nil {
+		return fields
+	}
+
+	if metadata, ok := define.OperationMetadataFromContext(ctx); ok {
+		fields["client"] = metadata.ClientName
+		fields["operation"] = metadata.FullName
+	}
+
+	contextFieldsExtractorsLock.RLock()
+	extractors := contextFieldsExtractors
+	contextFieldsExtractorsLock.RUnlock()
+
+	for _, entry := range extractors {
+		for key, value := range entry.extractor(ctx) {
+			fields[key] = value
+		}
+	}
+
+	return fields
+}
+
+// TraceFieldsFromHeader returns the trace_id and span_id of the W3C traceparent header.
+func TraceFieldsFromHeader(header http.Header) map[string]interface{} {
+	// traceparent: {version}-{trace-id}-{parent-id}-{trace-flags}
+	parts := strings.Split(header.Get("traceparent"), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"trace_id": parts[1],
+		"span_id":  parts[2],
+	}
+}
@@ -0,0 +1,88 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// MaskedValue is used to replace the sensitive values.
+const MaskedValue = "******"
+
+// the headers which value should be masked entirely
+var sensitiveHeaders = map[string]struct{}{
+	"Authorization":       {},
+	"Proxy-Authorization": {},
+	"Cookie":              {},
+	"Set-Cookie":          {},
+	"X-Bkapi-Jwt":         {},
+}
+
+// the sensitive keys of the X-Bkapi-Authorization header
+var sensitiveAuthorizationParams = []string{
+	"bk_app_secret",
+	"app_secret",
+	"access_token",
+	"jwt",
+	"bk_token",
+	"bk_ticket",
+}
+
+func maskAuthorizationParams(value string) string {
+	var params map[string]interface{}
+	err := json.Unmarshal([]byte(value), &params)
+	if err != nil {
+		// not a json object, mask the whole value to be safe
+		return MaskedValue
+	}
+
+	for _, key := range sensitiveAuthorizationParams {
+		if _, ok := params[key]; ok {
+			params[key] = MaskedValue
+		}
+	}
+
+	masked, err := json.Marshal(params)
+	if err != nil {
+		return MaskedValue
+	}
+
+	return string(masked)
+}
+
+// MaskHeader returns a copy of the header with the sensitive values masked.
+func MaskHeader(header http.Header) http.Header {
+	masked := make(http.Header, len(header))
+
+	for key, values := range header {
+		canonicalKey := http.CanonicalHeaderKey(key)
+		maskedValues := make([]string, len(values))
+
+		for i, value := range values {
+			switch {
+			case canonicalKey == "X-Bkapi-Authorization":
+				maskedValues[i] = maskAuthorizationParams(value)
+			default:
+				if _, ok := sensitiveHeaders[canonicalKey]; ok {
+					maskedValues[i] = MaskedValue
+				} else {
+					maskedValues[i] = value
+				}
+			}
+		}
+
+		masked[key] = maskedValues
+	}
+
+	return masked
+}
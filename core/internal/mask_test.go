@@ -0,0 +1,57 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+var _ = Describe("Mask", func() {
+	It("should mask the sensitive headers", func() {
+		header := http.Header{
+			"Authorization": []string{"Bearer token"},
+			"Cookie":        []string{"bk_token=token"},
+			"Content-Type":  []string{"application/json"},
+		}
+
+		masked := internal.MaskHeader(header)
+		Expect(masked.Get("Authorization")).To(Equal(internal.MaskedValue))
+		Expect(masked.Get("Cookie")).To(Equal(internal.MaskedValue))
+		Expect(masked.Get("Content-Type")).To(Equal("application/json"))
+
+		// the original header should not be changed
+		Expect(header.Get("Authorization")).To(Equal("Bearer token"))
+	})
+
+	It("should mask the secrets of bkapi authorization", func() {
+		header := http.Header{
+			"X-Bkapi-Authorization": []string{`{"bk_app_code":"app","bk_app_secret":"secret"}`},
+		}
+
+		masked := internal.MaskHeader(header)
+		Expect(masked.Get("X-Bkapi-Authorization")).To(Equal(`{"bk_app_code":"app","bk_app_secret":"******"}`))
+	})
+
+	It("should mask the whole bkapi authorization when it is not a json", func() {
+		header := http.Header{
+			"X-Bkapi-Authorization": []string{"secret"},
+		}
+
+		masked := internal.MaskHeader(header)
+		Expect(masked.Get("X-Bkapi-Authorization")).To(Equal(internal.MaskedValue))
+	})
+})
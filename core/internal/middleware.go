@@ -0,0 +1,84 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"net/http"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+// RoundTripperFunc adapts a define.RoundTrip to http.RoundTripper.
+type RoundTripperFunc define.RoundTrip
+
+// RoundTrip calls the function.
+func (f RoundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// ChainMiddlewares wraps the round trip with the middlewares, the first middleware is the outermost one.
+func ChainMiddlewares(roundTrip define.RoundTrip, middlewares []define.Middleware) define.RoundTrip {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		roundTrip = middlewares[i](roundTrip)
+	}
+
+	return roundTrip
+}
+
+// joinMiddlewares returns the middlewares followed by the inner ones, the first middleware is the outermost one.
+func joinMiddlewares(middlewares, inner []define.Middleware) []define.Middleware {
+	if len(inner) == 0 {
+		return middlewares
+	}
+
+	joined := make([]define.Middleware, 0, len(middlewares)+len(inner))
+	joined = append(joined, middlewares...)
+
+	return append(joined, inner...)
+}
+
+// WrapTransport wraps the transport with the middlewares, a nil transport means http.DefaultTransport.
+func WrapTransport(transport http.RoundTripper, middlewares []define.Middleware) http.RoundTripper {
+	if len(middlewares) == 0 {
+		return transport
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return RoundTripperFunc(ChainMiddlewares(transport.RoundTrip, middlewares))
+}
+
+// WrapOperationTransport wraps the transport with the middlewares of an operation,
+// the operation metadata is injected into the request context, so the middlewares can access it.
+func WrapOperationTransport(
+	transport http.RoundTripper,
+	metadata define.OperationMetadata,
+	middlewares []define.Middleware,
+) http.RoundTripper {
+	if len(middlewares) == 0 {
+		return transport
+	}
+
+	wrapped := WrapTransport(transport, middlewares)
+
+	return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		ctx := define.WithOperationMetadata(request.Context(), metadata)
+		return wrapped.RoundTrip(request.WithContext(ctx))
+	})
+}
+
+// middlewareUser is implemented by the operations of all the backends.
+type middlewareUser interface {
+	UseMiddlewares(middlewares ...define.Middleware)
+}
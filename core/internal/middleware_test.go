@@ -0,0 +1,71 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+var _ = Describe("Middleware", func() {
+	var calls []string
+
+	BeforeEach(func() {
+		calls = nil
+	})
+
+	newMiddleware := func(name string) define.Middleware {
+		return func(next define.RoundTrip) define.RoundTrip {
+			return func(request *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				response, err := next(request)
+				calls = append(calls, name+" after")
+
+				return response, err
+			}
+		}
+	}
+
+	roundTrip := func(request *http.Request) (*http.Response, error) {
+		calls = append(calls, "round trip")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}
+
+	It("should chain the middlewares in order", func() {
+		chained := internal.ChainMiddlewares(roundTrip, []define.Middleware{newMiddleware("a"), newMiddleware("b")})
+
+		_, err := chained(&http.Request{})
+		Expect(err).To(BeNil())
+		Expect(calls).To(Equal([]string{"a before", "b before", "round trip", "b after", "a after"}))
+	})
+
+	It("should return the transport directly when there is no middleware", func() {
+		transport := internal.RoundTripperFunc(roundTrip)
+
+		Expect(internal.WrapTransport(transport, nil)).To(BeAssignableToTypeOf(transport))
+	})
+
+	It("should wrap the transport", func() {
+		transport := internal.WrapTransport(internal.RoundTripperFunc(roundTrip), []define.Middleware{
+			newMiddleware("a"),
+		})
+
+		_, err := transport.RoundTrip(&http.Request{})
+		Expect(err).To(BeNil())
+		Expect(calls).To(Equal([]string{"a before", "round trip", "a after"}))
+	})
+})
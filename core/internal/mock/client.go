@@ -70,6 +70,20 @@ func (mr *MockBkApiClientMockRecorder) Apply(opts ...interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockBkApiClient)(nil).Apply), opts...)
 }
 
+// Close mocks base method.
+func (m *MockBkApiClient) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockBkApiClientMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockBkApiClient)(nil).Close))
+}
+
 // Name mocks base method.
 func (m *MockBkApiClient) Name() string {
 	m.ctrl.T.Helper()
@@ -103,6 +117,43 @@ func (mr *MockBkApiClientMockRecorder) NewOperation(config interface{}, opts ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewOperation", reflect.TypeOf((*MockBkApiClient)(nil).NewOperation), varargs...)
 }
 
+// MockConnectionStatsProvider is a mock of ConnectionStatsProvider interface.
+type MockConnectionStatsProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockConnectionStatsProviderMockRecorder
+}
+
+// MockConnectionStatsProviderMockRecorder is the mock recorder for MockConnectionStatsProvider.
+type MockConnectionStatsProviderMockRecorder struct {
+	mock *MockConnectionStatsProvider
+}
+
+// NewMockConnectionStatsProvider creates a new mock instance.
+func NewMockConnectionStatsProvider(ctrl *gomock.Controller) *MockConnectionStatsProvider {
+	mock := &MockConnectionStatsProvider{ctrl: ctrl}
+	mock.recorder = &MockConnectionStatsProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConnectionStatsProvider) EXPECT() *MockConnectionStatsProviderMockRecorder {
+	return m.recorder
+}
+
+// ConnectionStats mocks base method.
+func (m *MockConnectionStatsProvider) ConnectionStats() define.ConnectionStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectionStats")
+	ret0, _ := ret[0].(define.ConnectionStats)
+	return ret0
+}
+
+// ConnectionStats indicates an expected call of ConnectionStats.
+func (mr *MockConnectionStatsProviderMockRecorder) ConnectionStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectionStats", reflect.TypeOf((*MockConnectionStatsProvider)(nil).ConnectionStats))
+}
+
 // MockBkApiClientOption is a mock of BkApiClientOption interface.
 type MockBkApiClientOption struct {
 	ctrl     *gomock.Controller
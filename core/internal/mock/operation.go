@@ -56,6 +56,21 @@ func (mr *MockOperationMockRecorder) Apply(opts ...interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockOperation)(nil).Apply), opts...)
 }
 
+// Build mocks base method.
+func (m *MockOperation) Build(ctx context.Context) (*http.Request, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Build", ctx)
+	ret0, _ := ret[0].(*http.Request)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Build indicates an expected call of Build.
+func (mr *MockOperationMockRecorder) Build(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockOperation)(nil).Build), ctx)
+}
+
 // ClientName mocks base method.
 func (m *MockOperation) ClientName() string {
 	m.ctrl.T.Helper()
@@ -70,6 +85,21 @@ func (mr *MockOperationMockRecorder) ClientName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientName", reflect.TypeOf((*MockOperation)(nil).ClientName))
 }
 
+// Curl mocks base method.
+func (m *MockOperation) Curl() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Curl")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Curl indicates an expected call of Curl.
+func (mr *MockOperationMockRecorder) Curl() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Curl", reflect.TypeOf((*MockOperation)(nil).Curl))
+}
+
 // FullName mocks base method.
 func (m *MockOperation) FullName() string {
 	m.ctrl.T.Helper()
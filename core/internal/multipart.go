@@ -0,0 +1,67 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"sort"
+)
+
+// MultipartFile is a file part of the multipart form.
+type MultipartFile struct {
+	// Name is used as both the field name and the file name.
+	Name   string
+	Reader io.Reader
+}
+
+// NewMultipartBody encodes the fields and files as a multipart form, and returns the body with its content type.
+func NewMultipartBody(fields map[string][]string, files ...MultipartFile) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.Name, file.Name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		_, err = io.Copy(part, file.Reader)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	// keep the fields in order, so the body is stable
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range fields[key] {
+			err := writer.WriteField(key, value)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
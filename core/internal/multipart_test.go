@@ -0,0 +1,42 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"mime"
+	"mime/multipart"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+var _ = Describe("Multipart", func() {
+	It("should encode the fields and files", func() {
+		body, contentType, err := internal.NewMultipartBody(
+			map[string][]string{"hello": {"world"}},
+			internal.MultipartFile{Name: "testing.txt", Reader: strings.NewReader("content")},
+		)
+		Expect(err).To(BeNil())
+
+		mediaType, params, err := mime.ParseMediaType(contentType)
+		Expect(err).To(BeNil())
+		Expect(mediaType).To(Equal("multipart/form-data"))
+
+		form, err := multipart.NewReader(body, params["boundary"]).ReadForm(1024)
+		Expect(err).To(BeNil())
+		Expect(form.Value["hello"]).To(Equal([]string{"world"}))
+		Expect(form.File["testing.txt"]).To(HaveLen(1))
+	})
+})
@@ -17,12 +17,15 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"os"
+	"time"
 
 	gentleman "gopkg.in/h2non/gentleman.v2"
 	gmctx "gopkg.in/h2non/gentleman.v2/context"
 	"gopkg.in/h2non/gentleman.v2/plugin"
+	"gopkg.in/h2non/gentleman.v2/plugins/timeout"
 
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
 )
@@ -31,13 +34,20 @@ import (
 // and send the request.
 type Operation struct {
 	name           string
-	err            error
-	bodyData       interface{}
-	bodyProvider   define.BodyProvider
-	result         interface{}
-	resultProvider define.ResultProvider
-	request        *gentleman.Request
-	client         define.BkApiClient
+	path           string
+	idempotencyKey string
+	idempotent     bool
+	retryPolicy    *define.RetryPolicy
+	middlewares    []define.Middleware
+	// transportMiddlewares are inside the middlewares, so they see the final requests
+	transportMiddlewares []define.Middleware
+	err                  error
+	bodyData             interface{}
+	bodyProvider         define.BodyProvider
+	result               interface{}
+	resultProvider       define.ResultProvider
+	request              *gentleman.Request
+	client               define.BkApiClient
 }
 
 // Name returns the operation name.
@@ -61,6 +71,11 @@ func (op *Operation) String() string {
 	return fmt.Sprintf("%s %s", op.ClientName(), op.name)
 }
 
+// LogValue makes the operation logged by its full name in slog.
+func (op *Operation) LogValue() slog.Value {
+	return slog.StringValue(op.FullName())
+}
+
 // GetError returns the operation error.
 func (op *Operation) GetError() error {
 	return op.err
@@ -95,7 +110,7 @@ func (op *Operation) SetQueryParams(params map[string]string) define.Operation {
 // SetPathParams used to set the request path parameters.
 func (op *Operation) SetPathParams(params map[string]string) define.Operation {
 	op.request.Use(plugin.NewRequestPlugin(func(ctx *gmctx.Context, h gmctx.Handler) {
-		ctx.Request.URL.Path = ReplacePlaceHolder(ctx.Request.URL.Path, params)
+		ReplacePathPlaceHolder(ctx.Request.URL, params)
 
 		h.Next(ctx)
 	}))
@@ -138,8 +153,11 @@ func (op *Operation) SetResultProvider(provider define.ResultProvider) define.Op
 	return op
 }
 
-// SetContext used to set the request context.
+// SetContext used to set the request context, nil means context.Background().
 func (op *Operation) SetContext(ctx context.Context) define.Operation {
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	op.request.Context.SetCancelContext(ctx)
 
 	return op
@@ -164,6 +182,85 @@ func (op *Operation) SetFile(name string, file *os.File) define.Operation {
 	return op
 }
 
+// SetIdempotencyKey sets the idempotency key of the operation and sends it as a header.
+func (op *Operation) SetIdempotencyKey(key string) define.Operation {
+	op.idempotencyKey = key
+	op.request.SetHeader(define.IdempotencyKeyHeader, key)
+
+	return op
+}
+
+// IdempotencyKey returns the idempotency key of the operation.
+func (op *Operation) IdempotencyKey() string {
+	return op.idempotencyKey
+}
+
+// SetTimeout sets the timeout of the operation.
+func (op *Operation) SetTimeout(duration time.Duration) define.Operation {
+	op.request.Use(timeout.Request(duration))
+
+	return op
+}
+
+// SetIdempotent marks whether the operation is idempotent.
+func (op *Operation) SetIdempotent(idempotent bool) define.Operation {
+	op.idempotent = idempotent
+
+	return op
+}
+
+// SetRetryPolicy sets the retry policy of the operation,
+// only the idempotent operations or the operations with an idempotency key will be retried.
+func (op *Operation) SetRetryPolicy(policy *define.RetryPolicy) define.Operation {
+	op.retryPolicy = policy
+
+	return op
+}
+
+// Metadata returns the metadata of the operation.
+func (op *Operation) Metadata() define.OperationMetadata {
+	return define.OperationMetadata{
+		ClientName: op.ClientName(),
+		Name:       op.name,
+		FullName:   op.FullName(),
+		Path:       op.path,
+	}
+}
+
+// UseMiddlewares appends the middlewares to wrap the request execution.
+func (op *Operation) UseMiddlewares(middlewares ...define.Middleware) {
+	op.middlewares = append(op.middlewares, middlewares...)
+}
+
+// UseTransportMiddlewares appends the middlewares to wrap the transport, inside all the other middlewares.
+func (op *Operation) UseTransportMiddlewares(middlewares ...define.Middleware) {
+	op.transportMiddlewares = append(op.transportMiddlewares, middlewares...)
+}
+
+func (op *Operation) applyDialPolicies(ctx *gmctx.Context, h gmctx.Handler) {
+	retryable := isRetryable(op.retryPolicy, op.idempotent, op.idempotencyKey)
+	if !retryable {
+		SetRequestTimeoutHeader(ctx.Request, ctx.Client.Timeout)
+	}
+
+	// the retry should be the outermost, so the middlewares can observe each attempt
+	transport := ctx.Client.Transport
+	middlewares := joinMiddlewares(op.middlewares, op.transportMiddlewares)
+	if len(middlewares) > 0 {
+		transport = WrapOperationTransport(transport, op.Metadata(), middlewares)
+	}
+
+	if retryable {
+		// the time budget is computed for each attempt, so the retries advertise the time left
+		transport = WrapTransport(transport, []define.Middleware{NewRequestTimeoutMiddleware(ctx.Client.Timeout)})
+		transport = NewRetryRoundTripper(transport, *op.retryPolicy)
+	}
+
+	ctx.Client.Transport = transport
+
+	h.Next(ctx)
+}
+
 func (op *Operation) callBodyProvider() error {
 	if op.bodyProvider == nil {
 		return nil
@@ -206,14 +303,41 @@ func (op *Operation) checkBkapiError(response *gentleman.Response) error {
 	return detail.GetError()
 }
 
-// Request will send the operation request and return the response.
-func (op *Operation) Request() (*http.Response, error) {
+func (op *Operation) checkPathParams(ctx *gmctx.Context, h gmctx.Handler) {
+	keys := FindPlaceHolders(GetRawPath(ctx.Request.URL))
+	if len(keys) > 0 {
+		h.Error(ctx, &define.MissingPathParamError{Keys: keys})
+		return
+	}
+
+	h.Next(ctx)
+}
+
+func (op *Operation) prepare() error {
 	// when the operation already has an error, return it directly
 	if op.err != nil {
-		return nil, op.err
+		return op.err
+	}
+
+	// all the request plugins have been run before dialing, so the path should be completed
+	op.request.UseHandler("before dial", op.checkPathParams)
+	op.request.UseHandler("before dial", op.applyDialPolicies)
+
+	return op.callBodyProvider()
+}
+
+// Request will send the operation request and return the response.
+func (op *Operation) Request() (*http.Response, error) {
+	response, err := op.send()
+	if err != nil && op.idempotencyKey != "" {
+		return response, NewIdempotencyKeyError(err, op.idempotencyKey)
 	}
 
-	err := op.callBodyProvider()
+	return response, err
+}
+
+func (op *Operation) send() (*http.Response, error) {
+	err := op.prepare()
 	if err != nil {
 		return nil, err
 	}
@@ -236,6 +360,46 @@ func (op *Operation) Request() (*http.Response, error) {
 	return response.RawResponse, response.Error
 }
 
+func (op *Operation) build() (*http.Request, error) {
+	err := op.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	var request *http.Request
+	// stop the middleware chain before dialing, so no request will be sent
+	op.request.UseHandler("before dial", func(ctx *gmctx.Context, h gmctx.Handler) {
+		request = ctx.Request
+		h.Stop(ctx)
+	})
+
+	_, err = op.request.Send()
+	if err != nil {
+		return nil, define.ErrorWrapf(err, "failed to build operation %s", op)
+	}
+
+	return request, nil
+}
+
+// Build will run all the request plugins and body providers, and return the final request without sending it.
+func (op *Operation) Build(ctx context.Context) (*http.Request, error) {
+	if ctx != nil {
+		op.SetContext(ctx)
+	}
+
+	return op.build()
+}
+
+// Curl will build the operation and render the request as a curl command with the secrets masked.
+func (op *Operation) Curl() (string, error) {
+	request, err := op.build()
+	if err != nil {
+		return "", err
+	}
+
+	return NewCurlCommand(request, true)
+}
+
 // NewOperation creates a new operation.
 func NewOperation(name string, client define.BkApiClient, request *gentleman.Request) *Operation {
 	return &Operation{
@@ -18,7 +18,9 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -140,6 +142,38 @@ var _ = Describe("operation", func() {
 			Expect(response.Request.URL.Path).To(Equal("/hello/world"))
 		})
 
+		It("should escape request path params", func() {
+			mockTransportRoundTrip()
+
+			request := client.Request().Path("/hello/{name}")
+			operation = internal.NewOperation("test", bkapiClient, request)
+
+			response, err := operation.
+				SetPathParams(map[string]string{
+					"name": "a/b",
+				}).
+				Request()
+			Expect(err).To(BeNil())
+
+			Expect(response.Request.URL.EscapedPath()).To(Equal("/hello/a%2Fb"))
+		})
+
+		It("should fail when path params are missing", func() {
+			request := client.Request().Path("/{api_name}/stages/{stage_name}/")
+			operation = internal.NewOperation("test", bkapiClient, request)
+
+			_, err := operation.
+				SetPathParams(map[string]string{
+					"api_name": "testing",
+				}).
+				Request()
+
+			var missingErr *define.MissingPathParamError
+			Expect(errors.As(err, &missingErr)).To(BeTrue())
+			Expect(missingErr.Keys).To(Equal([]string{"stage_name"}))
+			Expect(errors.Cause(err)).To(Equal(define.ErrMissingPathParam))
+		})
+
 		It("should set request body", func() {
 			mockTransportRoundTrip()
 
@@ -232,6 +266,109 @@ var _ = Describe("operation", func() {
 			Expect(response.Close).To(BeTrue())
 		})
 
+		It("should build the request without sending", func() {
+			request := client.Request().Method("POST").URL("http://example.com/hello/{name}")
+			operation = internal.NewOperation("test", bkapiClient, request)
+
+			built, err := operation.
+				SetPathParams(map[string]string{
+					"name": "world",
+				}).
+				SetHeaders(map[string]string{
+					"X-Testing": "testing",
+				}).
+				SetBodyReader(strings.NewReader("testing")).
+				Build(context.Background())
+			Expect(err).To(BeNil())
+
+			Expect(built.Method).To(Equal("POST"))
+			Expect(built.URL.String()).To(Equal("http://example.com/hello/world"))
+			Expect(built.Header.Get("X-Testing")).To(Equal("testing"))
+
+			body, err := ioutil.ReadAll(built.Body)
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(Equal("testing"))
+		})
+
+		It("should render the request as curl command", func() {
+			request := client.Request().Method("GET").URL("http://example.com/")
+			operation = internal.NewOperation("test", bkapiClient, request)
+
+			command, err := operation.
+				SetHeaders(map[string]string{
+					"Authorization": "Bearer token",
+				}).
+				Curl()
+			Expect(err).To(BeNil())
+
+			Expect(command).To(ContainSubstring("'http://example.com/'"))
+			Expect(command).To(ContainSubstring("'Authorization: ******'"))
+			Expect(command).NotTo(ContainSubstring("token"))
+		})
+
+		It("should fail to build when the operation has an error", func() {
+			option := mock.NewMockOperationOption(ctrl)
+			option.EXPECT().ApplyToOperation(gomock.Any()).Return(errors.New("test"))
+
+			_, err := operation.Apply(option).Build(context.Background())
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should propagate the timeout by header", func() {
+			mockTransportRoundTrip()
+
+			response, err := operation.SetTimeout(time.Minute).Request()
+			Expect(err).To(BeNil())
+
+			timeout, err := strconv.Atoi(response.Request.Header.Get(define.RequestTimeoutHeader))
+			Expect(err).To(BeNil())
+			Expect(timeout).To(BeNumerically(">", 0))
+			Expect(timeout).To(BeNumerically("<=", 60000))
+		})
+
+		It("should not retry the operation which is not idempotent", func() {
+			response.StatusCode = http.StatusServiceUnavailable
+			mockTransport.EXPECT().RoundTrip(gomock.Any()).Return(response, nil).Times(1)
+
+			_, _ = operation.SetRetryPolicy(&define.RetryPolicy{MaxAttempts: 3}).Request()
+		})
+
+		It("should retry the idempotent operation", func() {
+			mockTransport.EXPECT().RoundTrip(gomock.Any()).Return(&http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}, nil)
+			mockTransportRoundTrip()
+
+			operation.SetIdempotent(true)
+			response, err := operation.
+				SetRetryPolicy(&define.RetryPolicy{MaxAttempts: 3}).
+				Request()
+			Expect(err).To(BeNil())
+			Expect(internal.GetRetryAttempt(response.Request.Context())).To(Equal(2))
+		})
+
+		It("should wrap the request by middlewares", func() {
+			bkapiClient.EXPECT().Name().Return("client").AnyTimes()
+			mockTransportRoundTrip()
+
+			var metadata define.OperationMetadata
+			_, err := operation.
+				Apply(internal.NewMiddlewareOption(func(next define.RoundTrip) define.RoundTrip {
+					return func(request *http.Request) (*http.Response, error) {
+						metadata, _ = define.OperationMetadataFromContext(request.Context())
+						return next(request)
+					}
+				})).
+				Request()
+			Expect(err).To(BeNil())
+			Expect(metadata).To(Equal(define.OperationMetadata{
+				ClientName: "client",
+				Name:       "test",
+				FullName:   "client.api.test",
+			}))
+		})
+
 		It("should return bkapi error", func() {
 			response.StatusCode = 403
 			response.Header = http.Header{
@@ -12,6 +12,9 @@
 package internal
 
 import (
+	"net/http"
+
+	gmctx "gopkg.in/h2non/gentleman.v2/context"
 	"gopkg.in/h2non/gentleman.v2/plugin"
 
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
@@ -29,6 +32,7 @@ func (o *PluginOption) ApplyToClient(cli define.BkApiClient) error {
 }
 
 // NewPluginOption creates a new PluginOption.
+// The plugins only work on the gentleman backend, use BackendOption for the options of all the backends.
 func NewPluginOption(plugins ...plugin.Plugin) *PluginOption {
 	var opt PluginOption
 	opt.BkApiClientOption = NewBkApiClientOption(func(cli *BkApiClient) error {
@@ -48,3 +52,151 @@ func NewPluginOption(plugins ...plugin.Plugin) *PluginOption {
 
 	return &opt
 }
+
+// RequestHook modifies the outgoing request.
+type RequestHook func(request *http.Request) error
+
+// HttpClientHook modifies the http client which sends the request.
+type HttpClientHook func(client *http.Client) error
+
+// TransportHook modifies the transport of the http client.
+type TransportHook func(transport *http.Transport)
+
+// BackendOption is an option which works on all the backends,
+// only one of the request hook, http client hook and middlewares will be set.
+type BackendOption struct {
+	plugin      plugin.Plugin
+	requestHook RequestHook
+	clientHook  HttpClientHook
+	// transportHook is set along with the clientHook, so the clients can configure a transport of their own
+	transportHook TransportHook
+	middlewares   []define.Middleware
+	// transportMiddlewares are the innermost ones, they see the requests as they are sent
+	transportMiddlewares []define.Middleware
+}
+
+// WithPlugin replaces the implementation of the gentleman backend by the given plugin,
+// it is useful to keep the original behaviors of the gentleman plugins.
+func (o *BackendOption) WithPlugin(p plugin.Plugin) *BackendOption {
+	o.plugin = p
+
+	return o
+}
+
+// ApplyToClient will apply the option to the client by the backend.
+func (o *BackendOption) ApplyToClient(cli define.BkApiClient) error {
+	switch client := cli.(type) {
+	case *BkApiClient:
+		// the client level http client is used when the client has a connection pool
+		if o.transportHook != nil && client.httpClient != nil {
+			return client.hookTransport(o.transportHook)
+		}
+
+		if o.clientHook != nil && client.httpClient != nil {
+			return o.clientHook(client.httpClient)
+		}
+
+		if o.plugin != nil {
+			client.client.Use(o.plugin)
+			return nil
+		}
+
+		return client.AddOperationOptions(o)
+	case *HttpBkApiClient:
+		return client.applyBackendOption(o)
+	default:
+		return define.ErrorWrapf(
+			define.ErrTypeNotMatch, "expected a backend client, got %T", cli,
+		)
+	}
+}
+
+// ApplyToOperation will apply the option to the operation by the backend.
+func (o *BackendOption) ApplyToOperation(op define.Operation) error {
+	switch operation := op.(type) {
+	case *Operation:
+		if o.plugin != nil {
+			operation.request.Use(o.plugin)
+			return nil
+		}
+
+		operation.UseMiddlewares(o.middlewares...)
+		operation.UseTransportMiddlewares(o.transportMiddlewares...)
+
+		return nil
+	case *HttpOperation:
+		return operation.applyBackendOption(o)
+	default:
+		return define.ErrorWrapf(
+			define.ErrTypeNotMatch, "expected a backend operation, got %T", op,
+		)
+	}
+}
+
+// NewRequestHookOption creates an option to modify the outgoing request.
+func NewRequestHookOption(hook RequestHook) *BackendOption {
+	return &BackendOption{
+		requestHook: hook,
+		plugin: plugin.NewRequestPlugin(func(ctx *gmctx.Context, h gmctx.Handler) {
+			err := hook(ctx.Request)
+			if err != nil {
+				h.Error(ctx, err)
+				return
+			}
+
+			h.Next(ctx)
+		}),
+	}
+}
+
+// NewHttpClientHookOption creates an option to modify the http client.
+func NewHttpClientHookOption(hook HttpClientHook) *BackendOption {
+	return &BackendOption{
+		clientHook: hook,
+		plugin: plugin.NewRequestPlugin(func(ctx *gmctx.Context, h gmctx.Handler) {
+			err := hook(ctx.Client)
+			if err != nil {
+				h.Error(ctx, err)
+				return
+			}
+
+			h.Next(ctx)
+		}),
+	}
+}
+
+// NewTransportHookOption creates an option to modify the transport. The client configures the transport of its pool,
+// which is copied from the borrowed one first, while an operation configures a copy of the transport for each request,
+// so the transports used by the others are never changed. A transport which is not a *http.Transport is left as is.
+func NewTransportHookOption(hook TransportHook) *BackendOption {
+	opt := NewHttpClientHookOption(func(client *http.Client) error {
+		transport, ok := client.Transport.(*http.Transport)
+		if ok {
+			transport = transport.Clone()
+			hook(transport)
+			client.Transport = transport
+		}
+
+		return nil
+	})
+	opt.transportHook = hook
+
+	return opt
+}
+
+// NewMiddlewareOption creates an option to wrap the request execution by the middlewares,
+// the first middleware is the outermost one.
+func NewMiddlewareOption(middlewares ...define.Middleware) *BackendOption {
+	return &BackendOption{
+		middlewares: middlewares,
+	}
+}
+
+// NewTransportMiddlewareOption creates an option to wrap the transport by the middlewares,
+// they are inside all the middlewares of NewMiddlewareOption, no matter where they are registered.
+// It is used by the middlewares which must see the final requests, like signing and wire dumping.
+func NewTransportMiddlewareOption(middlewares ...define.Middleware) *BackendOption {
+	return &BackendOption{
+		transportMiddlewares: middlewares,
+	}
+}
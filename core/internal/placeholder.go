@@ -12,17 +12,22 @@
 package internal
 
 import (
+	"net/url"
 	"regexp"
 	"strings"
 )
 
 var placeholderRe *regexp.Regexp
 
+func placeholderKey(placeholder string) string {
+	return strings.Trim(placeholder, "{ }")
+}
+
 // ReplacePlaceHolder replaces the placeholder with the given string.
 func ReplacePlaceHolder(s string, params map[string]string) string {
 	return placeholderRe.ReplaceAllStringFunc(
 		s, func(placeholder string) string {
-			key := strings.Trim(placeholder, "{ }")
+			key := placeholderKey(placeholder)
 			value, ok := params[key]
 			if !ok {
 				return placeholder
@@ -33,6 +38,72 @@ func ReplacePlaceHolder(s string, params map[string]string) string {
 	)
 }
 
+// FindPlaceHolders returns the keys of the placeholders in the given string.
+func FindPlaceHolders(s string) []string {
+	placeholders := placeholderRe.FindAllString(s, -1)
+	if len(placeholders) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(placeholders))
+	for _, placeholder := range placeholders {
+		keys = append(keys, placeholderKey(placeholder))
+	}
+
+	return keys
+}
+
+func escapePath(path string) string {
+	u := url.URL{Path: path}
+	return u.EscapedPath()
+}
+
+// GetRawPath returns the escaped path of the url, the placeholders in it are kept as they are.
+func GetRawPath(u *url.URL) string {
+	if u.RawPath != "" {
+		path, err := url.PathUnescape(u.RawPath)
+		if err == nil && path == u.Path {
+			return u.RawPath
+		}
+	}
+
+	// the placeholders should not be escaped
+	var builder strings.Builder
+	last := 0
+	for _, loc := range placeholderRe.FindAllStringIndex(u.Path, -1) {
+		builder.WriteString(escapePath(u.Path[last:loc[0]]))
+		builder.WriteString(u.Path[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	builder.WriteString(escapePath(u.Path[last:]))
+
+	return builder.String()
+}
+
+// ReplacePathPlaceHolder replaces the placeholders of the url path with the escaped values,
+// so the values containing slashes or spaces will not break the routing.
+func ReplacePathPlaceHolder(u *url.URL, params map[string]string) {
+	rawPath := placeholderRe.ReplaceAllStringFunc(
+		GetRawPath(u), func(placeholder string) string {
+			value, ok := params[placeholderKey(placeholder)]
+			if !ok {
+				return placeholder
+			}
+
+			return url.PathEscape(value)
+		},
+	)
+
+	path, err := url.PathUnescape(rawPath)
+	if err != nil {
+		// the raw path is escaped by us, so this should never happen.
+		return
+	}
+
+	u.Path = path
+	u.RawPath = rawPath
+}
+
 func init() {
 	// available placeholder pattern: {param} or { param }
 	placeholderRe = regexp.MustCompile(`{\s*.*?\s*}`)
@@ -12,6 +12,8 @@
 package internal_test
 
 import (
+	"net/url"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -47,4 +49,42 @@ var _ = Describe("Placeholder", func() {
 			)).To(Equal("hello world"))
 		})
 	})
+
+	Context("FindPlaceHolders", func() {
+		It("should return the keys of placeholders", func() {
+			Expect(internal.FindPlaceHolders("/{ api_name }/stages/{stage_name}/")).
+				To(Equal([]string{"api_name", "stage_name"}))
+		})
+
+		It("should return nil when no placeholder found", func() {
+			Expect(internal.FindPlaceHolders("/api/stages/")).To(BeNil())
+		})
+	})
+
+	Context("ReplacePathPlaceHolder", func() {
+		It("should escape the values", func() {
+			u, err := url.Parse("http://example.com/api/{name}/{action}/")
+			Expect(err).To(BeNil())
+
+			internal.ReplacePathPlaceHolder(u, map[string]string{
+				"name":   "a/b c",
+				"action": "get",
+			})
+
+			Expect(u.Path).To(Equal("/api/a/b c/get/"))
+			Expect(u.String()).To(Equal("http://example.com/api/a%2Fb%20c/get/"))
+		})
+
+		It("should keep the escaped values when replacing multiple times", func() {
+			u, err := url.Parse("http://example.com/api/{name}/{action}/")
+			Expect(err).To(BeNil())
+
+			internal.ReplacePathPlaceHolder(u, map[string]string{"name": "a/b"})
+			Expect(internal.FindPlaceHolders(internal.GetRawPath(u))).To(Equal([]string{"action"}))
+
+			internal.ReplacePathPlaceHolder(u, map[string]string{"action": "{get}"})
+			Expect(internal.FindPlaceHolders(internal.GetRawPath(u))).To(BeNil())
+			Expect(u.String()).To(Equal("http://example.com/api/a%2Fb/%7Bget%7D/"))
+		})
+	})
 })
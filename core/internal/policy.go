@@ -0,0 +1,63 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"time"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+// PolicyOperation is implemented by the operations of all the backends to set the execution policies.
+type PolicyOperation interface {
+	define.Operation
+
+	// SetIdempotencyKey sets the idempotency key of the operation and sends it as a header.
+	SetIdempotencyKey(key string) define.Operation
+	// IdempotencyKey returns the idempotency key of the operation.
+	IdempotencyKey() string
+	// SetTimeout sets the timeout of the operation.
+	SetTimeout(duration time.Duration) define.Operation
+	// SetIdempotent marks whether the operation is idempotent.
+	SetIdempotent(idempotent bool) define.Operation
+	// SetRetryPolicy sets the retry policy of the operation.
+	SetRetryPolicy(policy *define.RetryPolicy) define.Operation
+}
+
+// isRetryable checks whether the operation is safe to retry,
+// only the idempotent operations or the operations with an idempotency key will be retried.
+func isRetryable(policy *define.RetryPolicy, idempotent bool, idempotencyKey string) bool {
+	if policy == nil || policy.MaxAttempts <= 1 {
+		return false
+	}
+
+	return idempotent || idempotencyKey != ""
+}
+
+func applyOperationPolicy(op define.Operation, config define.OperationConfig) {
+	policy, ok := config.(define.OperationPolicyConfig)
+	if !ok {
+		return
+	}
+
+	operation, ok := op.(PolicyOperation)
+	if !ok {
+		return
+	}
+
+	if policy.GetTimeout() > 0 {
+		operation.SetTimeout(policy.GetTimeout())
+	}
+
+	operation.SetIdempotent(policy.IsIdempotent())
+	operation.SetRetryPolicy(policy.GetRetryPolicy())
+}
@@ -0,0 +1,210 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+// readonlyTransport hides the type of the transport, so the transport options will not modify it.
+type readonlyTransport struct {
+	*http.Transport
+}
+
+// poolEntry is the transport and the statistics shared by the pools of the same key.
+type poolEntry struct {
+	key       string
+	transport *http.Transport
+	trace     *httptrace.ClientTrace
+	refs      int
+
+	requests    int64
+	newConns    int64
+	reusedConns int64
+	idleTime    int64
+}
+
+func newPoolEntry(key string, transport *http.Transport) *poolEntry {
+	entry := &poolEntry{
+		key:       key,
+		transport: transport,
+		refs:      1,
+	}
+	entry.trace = &httptrace.ClientTrace{
+		GotConn: entry.gotConn,
+	}
+
+	return entry
+}
+
+func (e *poolEntry) gotConn(info httptrace.GotConnInfo) {
+	atomic.AddInt64(&e.requests, 1)
+
+	if !info.Reused {
+		atomic.AddInt64(&e.newConns, 1)
+		return
+	}
+
+	atomic.AddInt64(&e.reusedConns, 1)
+	atomic.AddInt64(&e.idleTime, int64(info.IdleTime))
+}
+
+// ConnectionPool is the connection pool used by a client,
+// it may share the transport with the other clients by a TransportRegistry.
+type ConnectionPool struct {
+	entry       *poolEntry
+	registry    *TransportRegistry
+	readonly    bool
+	borrowed    bool
+	releaseOnce sync.Once
+}
+
+// NewConnectionPool creates a connection pool which is owned by a single client.
+func NewConnectionPool(transport *http.Transport) *ConnectionPool {
+	return &ConnectionPool{
+		entry: newPoolEntry("", transport),
+	}
+}
+
+// BorrowConnectionPool creates a connection pool using a transport owned by others, such as http.DefaultTransport,
+// the transport is never changed or closed by the client, see Own.
+func BorrowConnectionPool(transport *http.Transport) *ConnectionPool {
+	return &ConnectionPool{
+		entry:    newPoolEntry("", transport),
+		readonly: true,
+		borrowed: true,
+	}
+}
+
+// Own returns a pool owned by the client, a borrowed pool is replaced by a pool of a copy of its transport,
+// so the transport can be configured without affecting the others.
+func (p *ConnectionPool) Own() *ConnectionPool {
+	if !p.borrowed {
+		return p
+	}
+
+	return NewConnectionPool(p.entry.transport.Clone())
+}
+
+// Transport returns the transport of the pool.
+func (p *ConnectionPool) Transport() *http.Transport {
+	return p.entry.transport
+}
+
+// RoundTripper returns the round tripper for the client, the transport acquired from the registry
+// is configured by the first client only, so it is hidden to the transport options of the others.
+func (p *ConnectionPool) RoundTripper() http.RoundTripper {
+	if p.readonly {
+		return readonlyTransport{Transport: p.entry.transport}
+	}
+
+	return p.entry.transport
+}
+
+// TraceRequest returns a request which reports the connection usage to the pool.
+func (p *ConnectionPool) TraceRequest(request *http.Request) *http.Request {
+	return request.WithContext(httptrace.WithClientTrace(request.Context(), p.entry.trace))
+}
+
+// Stats returns the connection reuse statistics of the pool,
+// the statistics are shared by the pools of the same transport.
+func (p *ConnectionPool) Stats() define.ConnectionStats {
+	return define.ConnectionStats{
+		Requests:    atomic.LoadInt64(&p.entry.requests),
+		NewConns:    atomic.LoadInt64(&p.entry.newConns),
+		ReusedConns: atomic.LoadInt64(&p.entry.reusedConns),
+		IdleTime:    time.Duration(atomic.LoadInt64(&p.entry.idleTime)),
+	}
+}
+
+// Release releases the pool, it is safe to call it multiple times.
+// The idle connections are closed when the transport is not used by any pool, a borrowed transport is left open.
+func (p *ConnectionPool) Release() {
+	p.releaseOnce.Do(func() {
+		if p.borrowed {
+			return
+		}
+
+		if p.registry != nil {
+			p.registry.release(p.entry)
+			return
+		}
+
+		p.entry.transport.CloseIdleConnections()
+	})
+}
+
+// TransportRegistry shares the transports by keys.
+type TransportRegistry struct {
+	lock    sync.Mutex
+	entries map[string]*poolEntry
+}
+
+// Acquire returns a pool using the transport of the key, the transport is created by the factory when not found.
+func (r *TransportRegistry) Acquire(key string, factory func() *http.Transport) *ConnectionPool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	entry, ok := r.entries[key]
+	if ok {
+		entry.refs++
+	} else {
+		entry = newPoolEntry(key, factory())
+		r.entries[key] = entry
+	}
+
+	return &ConnectionPool{
+		entry:    entry,
+		registry: r,
+		readonly: ok,
+	}
+}
+
+func (r *TransportRegistry) release(entry *poolEntry) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	entry.refs--
+	if entry.refs > 0 {
+		return
+	}
+
+	if r.entries[entry.key] == entry {
+		delete(r.entries, entry.key)
+	}
+
+	entry.transport.CloseIdleConnections()
+}
+
+// Len returns the number of the shared transports.
+func (r *TransportRegistry) Len() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return len(r.entries)
+}
+
+// NewTransportRegistry creates a new TransportRegistry.
+func NewTransportRegistry() *TransportRegistry {
+	return &TransportRegistry{
+		entries: make(map[string]*poolEntry),
+	}
+}
+
+// SharedTransportRegistry is the registry of the transports shared by the clients.
+var SharedTransportRegistry = NewTransportRegistry()
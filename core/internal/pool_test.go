@@ -0,0 +1,105 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+var _ = Describe("ConnectionPool", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should collect the connection reuse statistics", func() {
+		pool := internal.NewConnectionPool(&http.Transport{})
+		defer pool.Release()
+
+		client := &http.Client{Transport: pool.RoundTripper()}
+		for i := 0; i < 3; i++ {
+			request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			Expect(err).To(BeNil())
+
+			response, err := client.Do(pool.TraceRequest(request))
+			Expect(err).To(BeNil())
+			Expect(response.Body.Close()).To(Succeed())
+		}
+
+		stats := pool.Stats()
+		Expect(stats.Requests).To(Equal(int64(3)))
+		Expect(stats.NewConns).To(Equal(int64(1)))
+		Expect(stats.ReusedConns).To(Equal(int64(2)))
+	})
+
+	It("should share the transport by key", func() {
+		registry := internal.NewTransportRegistry()
+		created := 0
+		factory := func() *http.Transport {
+			created++
+			return &http.Transport{}
+		}
+
+		first := registry.Acquire("http://example.com", factory)
+		second := registry.Acquire("http://example.com", factory)
+		other := registry.Acquire("http://other.example.com", factory)
+
+		Expect(created).To(Equal(2))
+		Expect(registry.Len()).To(Equal(2))
+		Expect(second.Transport()).To(BeIdenticalTo(first.Transport()))
+		Expect(other.Transport()).NotTo(BeIdenticalTo(first.Transport()))
+
+		// only the first one can be configured by the transport options
+		_, ok := first.RoundTripper().(*http.Transport)
+		Expect(ok).To(BeTrue())
+		_, ok = second.RoundTripper().(*http.Transport)
+		Expect(ok).To(BeFalse())
+
+		first.Release()
+		first.Release()
+		Expect(registry.Len()).To(Equal(2))
+
+		second.Release()
+		other.Release()
+		Expect(registry.Len()).To(Equal(0))
+	})
+
+	It("should own a copy of the borrowed transport", func() {
+		transport := &http.Transport{}
+		pool := internal.BorrowConnectionPool(transport)
+
+		// the borrowed transport can not be configured by the transport options
+		_, ok := pool.RoundTripper().(*http.Transport)
+		Expect(ok).To(BeFalse())
+
+		owned := pool.Own()
+		Expect(owned.Transport()).NotTo(BeIdenticalTo(transport))
+		Expect(owned.RoundTripper()).To(BeIdenticalTo(owned.Transport()))
+		Expect(owned.Own()).To(BeIdenticalTo(owned))
+
+		pool.Release()
+		owned.Release()
+	})
+})
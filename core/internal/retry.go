@@ -0,0 +1,149 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+var defaultRetryOnStatus = []int{
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+type retryAttemptKey struct{}
+
+// WithRetryAttempt returns a new context carrying the attempt number of the request.
+func WithRetryAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, retryAttemptKey{}, attempt)
+}
+
+// GetRetryAttempt returns the attempt number of the request, the first attempt is 1.
+func GetRetryAttempt(ctx context.Context) int {
+	attempt, ok := ctx.Value(retryAttemptKey{}).(int)
+	if !ok {
+		return 1
+	}
+
+	return attempt
+}
+
+// RetryRoundTripper retries the requests by the retry policy.
+type RetryRoundTripper struct {
+	next   http.RoundTripper
+	policy define.RetryPolicy
+}
+
+func (t *RetryRoundTripper) shouldRetry(request *http.Request, response *http.Response, err error) bool {
+	// the request is canceled or timeout, there is no need to retry
+	if request.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	retryOnStatus := t.policy.RetryOnStatus
+	if len(retryOnStatus) == 0 {
+		retryOnStatus = defaultRetryOnStatus
+	}
+
+	for _, status := range retryOnStatus {
+		if response.StatusCode == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *RetryRoundTripper) backoff(attempt int) time.Duration {
+	backoff := t.policy.Backoff
+	for i := 1; i < attempt && backoff > 0; i++ {
+		backoff *= 2
+
+		if t.policy.MaxBackoff > 0 && backoff >= t.policy.MaxBackoff {
+			return t.policy.MaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+func (t *RetryRoundTripper) wait(request *http.Request, attempt int) error {
+	backoff := t.backoff(attempt)
+	if backoff <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-request.Context().Done():
+		return request.Context().Err()
+	}
+}
+
+// RoundTrip sends the request, and retries it when the policy allows.
+func (t *RetryRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	// the body should be buffered, so it can be sent again
+	body, err := ReadRequestBody(request)
+	if err != nil {
+		return nil, define.ErrorWrapf(err, "failed to read request body")
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptRequest := request.Clone(WithRetryAttempt(request.Context(), attempt))
+		if body != nil {
+			attemptRequest.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		response, err := t.next.RoundTrip(attemptRequest)
+		if attempt >= t.policy.MaxAttempts || !t.shouldRetry(request, response, err) {
+			return response, err
+		}
+
+		if response != nil {
+			// drain the body so the connection can be reused
+			_, _ = ioutil.ReadAll(response.Body)
+			response.Body.Close()
+		}
+
+		waitErr := t.wait(request, attempt)
+		if waitErr != nil {
+			return nil, waitErr
+		}
+	}
+}
+
+// NewRetryRoundTripper creates a new RetryRoundTripper.
+func NewRetryRoundTripper(next http.RoundTripper, policy define.RetryPolicy) *RetryRoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &RetryRoundTripper{
+		next:   next,
+		policy: policy,
+	}
+}
@@ -0,0 +1,139 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal/mock"
+)
+
+var _ = Describe("Retry", func() {
+	var (
+		ctrl          *gomock.Controller
+		mockTransport *mock.MockRoundTripper
+		request       *http.Request
+		bodies        []string
+		attempts      []int
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockTransport = mock.NewMockRoundTripper(ctrl)
+		bodies = nil
+		attempts = nil
+
+		var err error
+		request, err = http.NewRequest("POST", "http://example.com/api", strings.NewReader("body"))
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	mockRoundTrip := func(statusCodes ...int) {
+		for _, code := range statusCodes {
+			statusCode := code
+			mockTransport.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(
+				func(req *http.Request) (*http.Response, error) {
+					body, err := ioutil.ReadAll(req.Body)
+					Expect(err).To(BeNil())
+
+					bodies = append(bodies, string(body))
+					attempts = append(attempts, internal.GetRetryAttempt(req.Context()))
+
+					return &http.Response{
+						StatusCode: statusCode,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}, nil
+				},
+			)
+		}
+	}
+
+	It("should retry until success", func() {
+		mockRoundTrip(http.StatusServiceUnavailable, http.StatusOK)
+
+		roundTripper := internal.NewRetryRoundTripper(mockTransport, define.RetryPolicy{MaxAttempts: 3})
+		response, err := roundTripper.RoundTrip(request)
+
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(bodies).To(Equal([]string{"body", "body"}))
+		Expect(attempts).To(Equal([]int{1, 2}))
+	})
+
+	It("should stop retrying when reaching the max attempts", func() {
+		mockRoundTrip(http.StatusBadGateway, http.StatusBadGateway)
+
+		roundTripper := internal.NewRetryRoundTripper(mockTransport, define.RetryPolicy{MaxAttempts: 2})
+		response, err := roundTripper.RoundTrip(request)
+
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusBadGateway))
+		Expect(attempts).To(Equal([]int{1, 2}))
+	})
+
+	It("should not retry the status which is not configured", func() {
+		mockRoundTrip(http.StatusBadGateway)
+
+		roundTripper := internal.NewRetryRoundTripper(mockTransport, define.RetryPolicy{
+			MaxAttempts:   3,
+			RetryOnStatus: []int{http.StatusTooManyRequests},
+		})
+		response, err := roundTripper.RoundTrip(request)
+
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusBadGateway))
+	})
+
+	It("should retry on error", func() {
+		mockTransport.EXPECT().RoundTrip(gomock.Any()).Return(nil, errors.New("testing"))
+		mockRoundTrip(http.StatusOK)
+
+		roundTripper := internal.NewRetryRoundTripper(mockTransport, define.RetryPolicy{MaxAttempts: 2})
+		response, err := roundTripper.RoundTrip(request)
+
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should not retry when the context is canceled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		request = request.WithContext(ctx)
+
+		mockTransport.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			cancel()
+			return nil, context.Canceled
+		})
+
+		roundTripper := internal.NewRetryRoundTripper(mockTransport, define.RetryPolicy{MaxAttempts: 3})
+		_, err := roundTripper.RoundTrip(request)
+
+		Expect(err).To(MatchError(context.Canceled))
+	})
+
+	It("should return the first attempt by default", func() {
+		Expect(internal.GetRetryAttempt(context.Background())).To(Equal(1))
+	})
+})
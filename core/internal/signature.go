@@ -0,0 +1,97 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+const (
+	// SignatureTimestampHeader is the header of the unix timestamp when the request is signed.
+	SignatureTimestampHeader = "X-Bkapi-Timestamp"
+	// SignatureNonceHeader is the header of the random nonce which protects the request from replaying.
+	SignatureNonceHeader = "X-Bkapi-Nonce"
+	// SignatureHeader is the header of the HMAC-SHA256 signature.
+	SignatureHeader = "X-Bkapi-Signature"
+)
+
+// NewSignatureNonce generates a random nonce.
+func NewSignatureNonce() (string, error) {
+	nonce := make([]byte, 16)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", define.ErrorWrapf(err, "failed to generate signature nonce")
+	}
+
+	return hex.EncodeToString(nonce), nil
+}
+
+// CanonicalRequest renders the content to sign, which is joined by the lines of
+// method, escaped path, sorted query, timestamp, nonce and the hex encoded SHA256 of the body.
+func CanonicalRequest(request *http.Request, timestamp, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+
+	return strings.Join([]string{
+		strings.ToUpper(request.Method),
+		request.URL.EscapedPath(),
+		request.URL.Query().Encode(),
+		timestamp,
+		nonce,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+}
+
+// ComputeSignature returns the base64 encoded HMAC-SHA256 signature of the canonical request.
+func ComputeSignature(key []byte, canonicalRequest string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(canonicalRequest))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// NewSignatureMiddleware creates a middleware which signs each attempt of the requests by the key.
+func NewSignatureMiddleware(key string) define.Middleware {
+	return func(next define.RoundTrip) define.RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+			body, err := ReadRequestBody(request)
+			if err != nil {
+				return nil, define.ErrorWrapf(err, "failed to read body to sign")
+			}
+
+			nonce, err := NewSignatureNonce()
+			if err != nil {
+				return nil, err
+			}
+
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			signature := ComputeSignature([]byte(key), CanonicalRequest(request, timestamp, nonce, body))
+
+			// the round tripper should not modify the original request
+			signed := request.Clone(request.Context())
+			signed.Header.Set(SignatureTimestampHeader, timestamp)
+			signed.Header.Set(SignatureNonceHeader, nonce)
+			signed.Header.Set(SignatureHeader, signature)
+
+			return next(signed)
+		}
+	}
+}
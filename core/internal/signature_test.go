@@ -0,0 +1,61 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package internal_test
+
+import (
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/internal"
+)
+
+var _ = Describe("Signature", func() {
+	It("should render the canonical request", func() {
+		request, err := http.NewRequest("post", "http://example.com/prod/hello%20world?b=2&a=1", nil)
+		Expect(err).To(BeNil())
+
+		Expect(internal.CanonicalRequest(request, "1700000000", "nonce", []byte("body"))).To(Equal(strings.Join([]string{
+			"POST",
+			"/prod/hello%20world",
+			"a=1&b=2",
+			"1700000000",
+			"nonce",
+			"230d8358dc8e8890b4c58deeb62912ee2f20357ae92a5cc861b98e68fe31acb5",
+		}, "\n")))
+	})
+
+	It("should sign the request without modifying it", func() {
+		request, err := http.NewRequest("POST", "http://example.com/hello", strings.NewReader("body"))
+		Expect(err).To(BeNil())
+
+		var signed *http.Request
+		roundTrip := internal.NewSignatureMiddleware("secret")(func(r *http.Request) (*http.Response, error) {
+			signed = r
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		})
+
+		_, err = roundTrip(request)
+		Expect(err).To(BeNil())
+
+		Expect(request.Header.Get(internal.SignatureHeader)).To(BeEmpty())
+
+		timestamp := signed.Header.Get(internal.SignatureTimestampHeader)
+		nonce := signed.Header.Get(internal.SignatureNonceHeader)
+		Expect(nonce).To(HaveLen(32))
+		Expect(signed.Header.Get(internal.SignatureHeader)).To(Equal(internal.ComputeSignature(
+			[]byte("secret"), internal.CanonicalRequest(request, timestamp, nonce, []byte("body")),
+		)))
+	})
+})
@@ -0,0 +1,61 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package prometheus
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"syscall"
+)
+
+// the bounded values of the error label
+const (
+	errorTimeout  = "timeout"
+	errorDNS      = "dns"
+	errorRefused  = "refused"
+	errorTLS      = "tls"
+	errorCanceled = "canceled"
+	errorOther    = "other"
+)
+
+// classifyError returns the type of the error, so the cardinality of the error label is bounded.
+func classifyError(err error) string {
+	var (
+		dnsError            *net.DNSError
+		recordHeaderError   tls.RecordHeaderError
+		alertError          tls.AlertError
+		verificationError   *tls.CertificateVerificationError
+		unknownAuthorityErr x509.UnknownAuthorityError
+		certificateInvalid  x509.CertificateInvalidError
+		hostnameError       x509.HostnameError
+		netError            net.Error
+	)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return errorCanceled
+	case errors.As(err, &dnsError):
+		return errorDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return errorRefused
+	case errors.As(err, &recordHeaderError), errors.As(err, &alertError), errors.As(err, &verificationError),
+		errors.As(err, &unknownAuthorityErr), errors.As(err, &certificateInvalid), errors.As(err, &hostnameError):
+		return errorTLS
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netError) && netError.Timeout():
+		return errorTimeout
+	default:
+		return errorOther
+	}
+}
@@ -12,12 +12,14 @@
 package prometheus
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptrace"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"gopkg.in/h2non/gentleman.v2/context"
 
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
@@ -31,20 +33,44 @@ type PrometheusOptions struct {
 	ConstLabels     prometheus.Labels
 	DurationBuckets []float64
 	BytesBuckets    []float64
-	Registerer      prometheus.Registerer
+	// PhaseBuckets are the buckets of the request phase durations in seconds, defaults to prometheus.DefBuckets.
+	PhaseBuckets []float64
+	Registerer   prometheus.Registerer
 }
 
 type bkapiCollector struct {
-	*internal.OperationOption
+	*bkapi.OperationOption
 	metricRequestsDurationSeconds *prometheus.HistogramVec
 	metricRequestsBodyBytes       *prometheus.HistogramVec
 	metricResponsesBodyBytes      *prometheus.HistogramVec
 	metricResponsesTotal          *prometheus.CounterVec
 	metricResponsesFailuresTotal  *prometheus.CounterVec
+	metricRequestsInFlight        *prometheus.GaugeVec
+	metricRequestPhaseSeconds     *prometheus.HistogramVec
+	metricGatewayErrorsTotal      *prometheus.CounterVec
+	metricRetriesTotal            *prometheus.CounterVec
 }
 
-func (c *bkapiCollector) init(opt PrometheusOptions) {
-	c.OperationOption = internal.NewOperationOption(c.collectMetrics)
+// register registers the metric, the metric registered by another collector with the same options is reused,
+// so the collectors of the same registerer can be created multiple times.
+func register[T prometheus.Collector](registerer prometheus.Registerer, metric T) (T, error) {
+	err := registerer.Register(metric)
+	if err == nil {
+		return metric, nil
+	}
+
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		if existing, ok := registered.ExistingCollector.(T); ok {
+			return existing, nil
+		}
+	}
+
+	return metric, define.ErrorWrapf(err, "failed to register metric")
+}
+
+func (c *bkapiCollector) init(opt PrometheusOptions) (err error) {
+	c.OperationOption = bkapi.NewOperationOption(c.collectMetrics)
 
 	registerer := opt.Registerer
 
@@ -58,7 +84,10 @@ func (c *bkapiCollector) init(opt PrometheusOptions) {
 			Help:        "Histogram of requests duration by operation, method",
 		}, []string{"operation", "method"},
 	)
-	registerer.MustRegister(c.metricRequestsDurationSeconds)
+	c.metricRequestsDurationSeconds, err = register(registerer, c.metricRequestsDurationSeconds)
+	if err != nil {
+		return err
+	}
 
 	c.metricRequestsBodyBytes = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -70,7 +99,10 @@ func (c *bkapiCollector) init(opt PrometheusOptions) {
 			Help:        "Histogram of requests body bytes by operation, method",
 		}, []string{"operation", "method"},
 	)
-	registerer.MustRegister(c.metricRequestsBodyBytes)
+	c.metricRequestsBodyBytes, err = register(registerer, c.metricRequestsBodyBytes)
+	if err != nil {
+		return err
+	}
 
 	c.metricResponsesBodyBytes = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -82,7 +114,10 @@ func (c *bkapiCollector) init(opt PrometheusOptions) {
 			Help:        "Histogram of responses body bytes by operation, method",
 		}, []string{"operation", "method"},
 	)
-	registerer.MustRegister(c.metricResponsesBodyBytes)
+	c.metricResponsesBodyBytes, err = register(registerer, c.metricResponsesBodyBytes)
+	if err != nil {
+		return err
+	}
 
 	c.metricResponsesTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -93,7 +128,10 @@ func (c *bkapiCollector) init(opt PrometheusOptions) {
 			Help:        "Count of responses by operation, method, status",
 		}, []string{"operation", "method", "status"},
 	)
-	registerer.MustRegister(c.metricResponsesTotal)
+	c.metricResponsesTotal, err = register(registerer, c.metricResponsesTotal)
+	if err != nil {
+		return err
+	}
 
 	c.metricResponsesFailuresTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -101,63 +139,134 @@ func (c *bkapiCollector) init(opt PrometheusOptions) {
 			Subsystem:   opt.Subsystem,
 			ConstLabels: opt.ConstLabels,
 			Name:        "bkapi_failures_total",
-			Help:        "Count of failures by operation, method",
+			Help:        "Count of failures by operation, method, error type",
 		}, []string{"operation", "method", "error"},
 	)
-	registerer.MustRegister(c.metricResponsesFailuresTotal)
-}
+	c.metricResponsesFailuresTotal, err = register(registerer, c.metricResponsesFailuresTotal)
+	if err != nil {
+		return err
+	}
 
-func (c *bkapiCollector) collectMetrics(operation *internal.Operation) error {
-	var (
-		requestStart time.Time
-		name         = operation.FullName()
-		request      = internal.GetOperationRawRequest(operation)
+	c.metricRequestsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   opt.Namespace,
+			Subsystem:   opt.Subsystem,
+			ConstLabels: opt.ConstLabels,
+			Name:        "bkapi_requests_in_flight",
+			Help:        "Number of requests in flight by operation, method",
+		}, []string{"operation", "method"},
 	)
+	c.metricRequestsInFlight, err = register(registerer, c.metricRequestsInFlight)
+	if err != nil {
+		return err
+	}
 
-	request.UseHandler("before dial", func(ctx *context.Context, h context.Handler) {
-		requestStart = time.Now()
-		h.Next(ctx)
-	})
+	c.metricRequestPhaseSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace:   opt.Namespace,
+			Subsystem:   opt.Subsystem,
+			ConstLabels: opt.ConstLabels,
+			Buckets:     opt.PhaseBuckets,
+			Name:        "bkapi_request_phase_duration_seconds",
+			Help:        "Histogram of request phases (dns, connect, tls, first_byte) duration by operation, phase",
+		}, []string{"operation", "phase"},
+	)
+	c.metricRequestPhaseSeconds, err = register(registerer, c.metricRequestPhaseSeconds)
+	if err != nil {
+		return err
+	}
+
+	c.metricGatewayErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   opt.Namespace,
+			Subsystem:   opt.Subsystem,
+			ConstLabels: opt.ConstLabels,
+			Name:        "bkapi_gateway_errors_total",
+			Help:        "Count of gateway errors by operation, method, X-Bkapi-Error-Code",
+		}, []string{"operation", "method", "code"},
+	)
+	c.metricGatewayErrorsTotal, err = register(registerer, c.metricGatewayErrorsTotal)
+	if err != nil {
+		return err
+	}
+
+	c.metricRetriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   opt.Namespace,
+			Subsystem:   opt.Subsystem,
+			ConstLabels: opt.ConstLabels,
+			Name:        "bkapi_retries_total",
+			Help:        "Count of retried attempts by operation, method",
+		}, []string{"operation", "method"},
+	)
+	c.metricRetriesTotal, err = register(registerer, c.metricRetriesTotal)
+	if err != nil {
+		return err
+	}
 
-	request.UseHandler("after dial", func(ctx *context.Context, h context.Handler) {
-		requestEnd := time.Now()
-		defer h.Next(ctx)
+	return nil
+}
 
-		method := ctx.Request.Method
-		status := strconv.Itoa(ctx.Response.StatusCode)
-		c.metricResponsesTotal.WithLabelValues(name, method, status).Inc()
+func (c *bkapiCollector) observeResponse(name string, request *http.Request, response *http.Response, duration time.Duration) {
+	method := request.Method
+	status := strconv.Itoa(response.StatusCode)
+	c.metricResponsesTotal.WithLabelValues(name, method, status).Inc()
+	c.metricRequestsDurationSeconds.WithLabelValues(name, method).Observe(duration.Seconds())
 
-		if !requestStart.IsZero() {
-			c.metricRequestsDurationSeconds.WithLabelValues(name, method).
-				Observe(requestEnd.Sub(requestStart).Seconds())
-		}
+	requestContentLength, err := strconv.ParseFloat(request.Header.Get("Content-Length"), 64)
+	if err != nil && request.ContentLength > 0 {
+		requestContentLength, err = float64(request.ContentLength), nil
+	}
+	if err == nil {
+		c.metricRequestsBodyBytes.WithLabelValues(name, method).Observe(requestContentLength)
+	}
 
-		requestContentLength, err := strconv.ParseFloat(ctx.Request.Header.Get("Content-Length"), 64)
-		if err == nil {
-			c.metricRequestsBodyBytes.WithLabelValues(name, method).Observe(requestContentLength)
-		}
+	responseContentLength, err := strconv.ParseFloat(response.Header.Get("Content-Length"), 64)
+	if err == nil {
+		c.metricResponsesBodyBytes.WithLabelValues(name, method).Observe(responseContentLength)
+	}
 
-		responseContentLength, err := strconv.ParseFloat(ctx.Response.Header.Get("Content-Length"), 64)
-		if err == nil {
-			c.metricResponsesBodyBytes.WithLabelValues(name, method).Observe(responseContentLength)
-		}
-	})
+	errorCode := response.Header.Get("X-Bkapi-Error-Code")
+	if errorCode != "" {
+		c.metricGatewayErrorsTotal.WithLabelValues(name, method, errorCode).Inc()
+	}
+}
 
-	request.UseHandler("error", func(ctx *context.Context, h context.Handler) {
-		defer h.Next(ctx)
+func (c *bkapiCollector) middleware(name string) define.Middleware {
+	return func(next define.RoundTrip) define.RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+			inFlight := c.metricRequestsInFlight.WithLabelValues(name, request.Method)
+			inFlight.Inc()
+			defer inFlight.Dec()
 
-		cause := define.ErrorCause(ctx.Error)
-		if cause == nil {
-			return
-		}
+			if internal.GetRetryAttempt(request.Context()) > 1 {
+				c.metricRetriesTotal.WithLabelValues(name, request.Method).Inc()
+			}
 
-		c.metricResponsesFailuresTotal.WithLabelValues(name, ctx.Request.Method, cause.Error()).Inc()
-	})
+			requestStart := time.Now()
+			trace := newPhaseTrace(c.metricRequestPhaseSeconds, name, requestStart)
+			request = request.WithContext(httptrace.WithClientTrace(request.Context(), trace.clientTrace()))
 
-	return nil
+			response, err := next(request)
+			if err != nil {
+				c.metricResponsesFailuresTotal.WithLabelValues(name, request.Method, classifyError(err)).Inc()
+
+				return response, err
+			}
+
+			c.observeResponse(name, request, response, time.Since(requestStart))
+
+			return response, nil
+		}
+	}
+}
+
+// collectMetrics wraps the operation by a middleware, so it works on all the backends.
+func (c *bkapiCollector) collectMetrics(operation define.Operation) error {
+	return internal.NewMiddlewareOption(c.middleware(operation.FullName())).ApplyToOperation(operation)
 }
 
-func initCollector(collector *bkapiCollector, opt PrometheusOptions) {
+func newBkapiCollector(opt PrometheusOptions) (*bkapiCollector, error) {
 	if opt.DurationBuckets == nil {
 		opt.DurationBuckets = []float64{
 			1,
@@ -203,23 +312,43 @@ func initCollector(collector *bkapiCollector, opt PrometheusOptions) {
 		}
 	}
 
+	if opt.PhaseBuckets == nil {
+		opt.PhaseBuckets = prometheus.DefBuckets
+	}
+
 	if opt.Registerer == nil {
 		opt.Registerer = prometheus.DefaultRegisterer
 	}
 
-	collector.init(opt)
+	collector := &bkapiCollector{}
+	err := collector.init(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return collector, nil
 }
 
-var (
-	initOnce             sync.Once
-	globalBkapiCollector bkapiCollector
-)
+// NewCollector creates a collector which collects the metrics to the registerer of the options,
+// it can be applied to the selected clients, operations or registered as a global option.
+// The collectors of the same registerer and options share the metrics,
+// and a nil registerer means prometheus.DefaultRegisterer.
+func NewCollector(opt PrometheusOptions) (define.BkApiOption, error) {
+	return newBkapiCollector(opt)
+}
+
+var initOnce sync.Once
 
-// Enable prometheus metrics
+// Enable prometheus metrics for all the clients created afterwards, only the first call takes effect.
+// It panics when the metrics cannot be registered, use NewCollector to handle the error.
 func Enable(opt PrometheusOptions) (ok bool) {
 	initOnce.Do(func() {
-		initCollector(&globalBkapiCollector, opt)
-		bkapi.RegisterGlobalBkapiClientOption(globalBkapiCollector)
+		collector, err := NewCollector(opt)
+		if err != nil {
+			panic(err)
+		}
+
+		bkapi.RegisterGlobalBkapiClientOption(collector)
 
 		ok = true
 	})
@@ -12,9 +12,16 @@
 package prometheus
 
 import (
+	"context"
+	"crypto/x509"
 	"fmt"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
 	"strconv"
+	"syscall"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -51,11 +58,13 @@ var _ = Describe("Plugin", func() {
 			Header:     http.Header{},
 		}
 
-		collector = &bkapiCollector{}
 		registry = prometheus.NewRegistry()
-		initCollector(collector, PrometheusOptions{
+
+		var err error
+		collector, err = newBkapiCollector(PrometheusOptions{
 			Registerer: registry,
 		})
+		Expect(err).To(BeNil())
 
 		operationConfig = bkapi.OperationConfig{
 			Name:   "demo",
@@ -66,7 +75,6 @@ var _ = Describe("Plugin", func() {
 		clientConfig = bkapi.ClientConfig{
 			Endpoint: "http://example.com",
 		}
-		var err error
 		client, err = bkapi.NewBkApiClient(apiName, clientConfig, collector, bkapi.OptTransport(mockTransport))
 		Expect(err).To(BeNil())
 
@@ -103,7 +111,7 @@ var _ = Describe("Plugin", func() {
 					}
 
 					if value != *label.Value {
-						break outer
+						continue outer
 					}
 				}
 
@@ -241,4 +249,229 @@ var _ = Describe("Plugin", func() {
 			Expect(metric).NotTo(BeNil())
 		})
 	})
+
+	Context("native backend", func() {
+		BeforeEach(func() {
+			clientConfig.Backend = define.BackendNative
+
+			var err error
+			client, err = bkapi.NewBkApiClient(apiName, clientConfig, collector, bkapi.OptTransport(mockTransport))
+			Expect(err).To(BeNil())
+		})
+
+		It("should record the responses", func() {
+			mockRequest()
+			_, err := client.NewOperation(operationConfig).Request()
+			Expect(err).To(BeNil())
+
+			metric := gatherMetric("bkapi_responses_total", map[string]string{
+				"operation": operationName,
+				"method":    operationConfig.Method,
+				"status":    "200",
+			})
+			Expect(metric).NotTo(BeNil())
+		})
+
+		It("should record the failures", func() {
+			requestError = fmt.Errorf("testing")
+
+			mockRequest()
+			_, err := client.NewOperation(operationConfig).Request()
+			Expect(err).NotTo(BeNil())
+
+			metric := gatherMetric("bkapi_failures_total", map[string]string{
+				"operation": operationName,
+				"method":    operationConfig.Method,
+			})
+			Expect(metric).NotTo(BeNil())
+		})
+	})
+
+	Context("bkapi_failures_total", func() {
+		It("should label the error by type", func() {
+			requestError = &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+
+			mockRequest()
+			_, err := client.NewOperation(operationConfig).Request()
+			Expect(err).NotTo(BeNil())
+
+			metric := gatherMetric("bkapi_failures_total", map[string]string{
+				"operation": operationName,
+				"error":     "refused",
+			})
+			Expect(metric).NotTo(BeNil())
+			Expect(metric.Counter.GetValue()).To(Equal(1.0))
+		})
+
+		DescribeTable("should classify the errors", func(err error, expected string) {
+			Expect(classifyError(define.ErrorWrapf(err, "wrapped"))).To(Equal(expected))
+		},
+			Entry("canceled", &url.Error{Op: "Get", URL: "/", Err: context.Canceled}, "canceled"),
+			Entry("deadline", context.DeadlineExceeded, "timeout"),
+			Entry("dns", &net.DNSError{Err: "no such host", Name: "example.com"}, "dns"),
+			Entry("refused", os.NewSyscallError("connect", syscall.ECONNREFUSED), "refused"),
+			Entry("tls", x509.UnknownAuthorityError{}, "tls"),
+			Entry("other", fmt.Errorf("testing"), "other"),
+		)
+	})
+
+	Context("bkapi_requests_in_flight", func() {
+		It("should count the requests in flight", func() {
+			var inFlight float64
+			mockTransport.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				inFlight = gatherMetric("bkapi_requests_in_flight", map[string]string{
+					"operation": operationName,
+				}).Gauge.GetValue()
+
+				response.Request = req
+				return response, nil
+			})
+
+			_, err := client.NewOperation(operationConfig).Request()
+			Expect(err).To(BeNil())
+
+			Expect(inFlight).To(Equal(1.0))
+			metric := gatherMetric("bkapi_requests_in_flight", map[string]string{
+				"operation": operationName,
+			})
+			Expect(metric.Gauge.GetValue()).To(Equal(0.0))
+		})
+	})
+
+	Context("bkapi_gateway_errors_total", func() {
+		It("should record by the error code", func() {
+			response.StatusCode = http.StatusTooManyRequests
+			response.Header.Set("X-Bkapi-Error-Code", "1642902")
+
+			mockRequest()
+			_, _ = client.NewOperation(operationConfig).Request()
+
+			metric := gatherMetric("bkapi_gateway_errors_total", map[string]string{
+				"operation": operationName,
+				"code":      "1642902",
+			})
+			Expect(metric).NotTo(BeNil())
+		})
+	})
+
+	Context("bkapi_retries_total", func() {
+		It("should count the retried attempts", func() {
+			statuses := []int{http.StatusServiceUnavailable, http.StatusOK}
+			mockTransport.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				status := statuses[0]
+				statuses = statuses[1:]
+
+				return &http.Response{StatusCode: status, Header: http.Header{}, Body: http.NoBody, Request: req}, nil
+			}).Times(2)
+
+			_, err := client.NewOperation(bkapi.OperationConfig{
+				Name:        "retry",
+				Method:      "GET",
+				Path:        "/retry",
+				Idempotent:  true,
+				RetryPolicy: &define.RetryPolicy{MaxAttempts: 2},
+			}).Request()
+			Expect(err).To(BeNil())
+
+			metric := gatherMetric("bkapi_retries_total", map[string]string{
+				"operation": fmt.Sprintf("%s.api.retry", apiName),
+			})
+			Expect(metric).NotTo(BeNil())
+			Expect(metric.Counter.GetValue()).To(Equal(1.0))
+		})
+	})
+
+	Context("bkapi_request_phase_duration_seconds", func() {
+		It("should record the phases of a new connection", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			phaseClient, err := bkapi.NewBkApiClient(apiName, bkapi.ClientConfig{
+				Endpoint: server.URL,
+				Backend:  define.BackendNative,
+			}, collector)
+			Expect(err).To(BeNil())
+			defer phaseClient.Close()
+
+			_, err = phaseClient.NewOperation(operationConfig).Request()
+			Expect(err).To(BeNil())
+
+			for _, phase := range []string{"connect", "first_byte"} {
+				metric := gatherMetric("bkapi_request_phase_duration_seconds", map[string]string{
+					"operation": operationName,
+					"phase":     phase,
+				})
+				Expect(metric).NotTo(BeNil())
+				Expect(metric.Histogram.GetSampleCount()).To(Equal(uint64(1)))
+			}
+		})
+	})
+
+	Context("NewCollector", func() {
+		It("should collect to its own registerer with the const labels", func() {
+			otherRegistry := prometheus.NewRegistry()
+			otherCollector, err := NewCollector(PrometheusOptions{
+				Registerer:  otherRegistry,
+				ConstLabels: prometheus.Labels{"instance": "other"},
+			})
+			Expect(err).To(BeNil())
+
+			otherClient, err := bkapi.NewBkApiClient(
+				apiName, clientConfig, otherCollector, bkapi.OptTransport(mockTransport),
+			)
+			Expect(err).To(BeNil())
+
+			mockRequest()
+			_, err = otherClient.NewOperation(operationConfig).Request()
+			Expect(err).To(BeNil())
+
+			Expect(gatherMetric("bkapi_responses_total", nil)).To(BeNil())
+
+			results, err := otherRegistry.Gather()
+			Expect(err).To(BeNil())
+			Expect(results).NotTo(BeEmpty())
+
+			labels := map[string]string{}
+			for _, label := range results[0].Metric[0].Label {
+				labels[*label.Name] = *label.Value
+			}
+			Expect(labels).To(HaveKeyWithValue("instance", "other"))
+		})
+
+		It("should share the metrics of the same registerer", func() {
+			otherCollector, err := NewCollector(PrometheusOptions{Registerer: registry})
+			Expect(err).To(BeNil())
+
+			otherClient, err := bkapi.NewBkApiClient(
+				apiName, clientConfig, otherCollector, bkapi.OptTransport(mockTransport),
+			)
+			Expect(err).To(BeNil())
+
+			mockRequest()
+			_, err = client.NewOperation(operationConfig).Request()
+			Expect(err).To(BeNil())
+
+			mockRequest()
+			_, err = otherClient.NewOperation(operationConfig).Request()
+			Expect(err).To(BeNil())
+
+			metric := gatherMetric("bkapi_responses_total", map[string]string{
+				"operation": operationName,
+				"status":    "200",
+			})
+			Expect(metric.Counter.GetValue()).To(Equal(2.0))
+		})
+
+		It("should return an error when the metrics conflict", func() {
+			conflictRegistry := prometheus.NewRegistry()
+			conflictRegistry.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{
+				Name: "bkapi_requests_duration_seconds",
+			}))
+
+			_, err := NewCollector(PrometheusOptions{Registerer: conflictRegistry})
+			Expect(err).NotTo(BeNil())
+		})
+	})
 })
@@ -0,0 +1,100 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package prometheus
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// phaseTrace observes the durations of the request phases by httptrace,
+// the phases of a reused connection (dns, connect, tls) are not observed.
+type phaseTrace struct {
+	observer  *prometheus.HistogramVec
+	operation string
+	start     time.Time
+
+	lock     sync.Mutex
+	dnsStart time.Time
+	tlsStart time.Time
+	connects map[string]time.Time
+}
+
+func newPhaseTrace(observer *prometheus.HistogramVec, operation string, start time.Time) *phaseTrace {
+	return &phaseTrace{
+		observer:  observer,
+		operation: operation,
+		start:     start,
+	}
+}
+
+func (t *phaseTrace) observe(phase string, start time.Time) {
+	if start.IsZero() {
+		return
+	}
+
+	t.observer.WithLabelValues(t.operation, phase).Observe(time.Since(start).Seconds())
+}
+
+func (t *phaseTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.lock.Lock()
+			t.dnsStart = time.Now()
+			t.lock.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.lock.Lock()
+			defer t.lock.Unlock()
+
+			t.observe("dns", t.dnsStart)
+		},
+		// the connections to multiple addresses may be dialed in parallel
+		ConnectStart: func(network, addr string) {
+			t.lock.Lock()
+			defer t.lock.Unlock()
+
+			if t.connects == nil {
+				t.connects = make(map[string]time.Time, 1)
+			}
+			t.connects[network+addr] = time.Now()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			t.lock.Lock()
+			defer t.lock.Unlock()
+
+			if err == nil {
+				t.observe("connect", t.connects[network+addr])
+			}
+		},
+		TLSHandshakeStart: func() {
+			t.lock.Lock()
+			t.tlsStart = time.Now()
+			t.lock.Unlock()
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			t.lock.Lock()
+			defer t.lock.Unlock()
+
+			if err == nil {
+				t.observe("tls", t.tlsStart)
+			}
+		},
+		GotFirstResponseByte: func() {
+			t.observe("first_byte", t.start)
+		},
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
 	"github.com/TencentBlueKing/bk-apigateway-sdks/demo"
 )
 
@@ -41,7 +42,15 @@ func newMockTransport() *mockTransport {
 }
 
 func Benchmark_Demo_Request(b *testing.B) {
-	client, _ := demo.New(bkapi.ClientConfig{}, bkapi.OptTransport(newMockTransport()))
+	benchmarkRequest(b, bkapi.ClientConfig{})
+}
+
+func Benchmark_Demo_Native_Request(b *testing.B) {
+	benchmarkRequest(b, bkapi.ClientConfig{Backend: define.BackendNative})
+}
+
+func benchmarkRequest(b *testing.B, config bkapi.ClientConfig) {
+	client, _ := demo.New(config, bkapi.OptTransport(newMockTransport()))
 
 	b.ResetTimer()
 	b.RunParallel(func(pb *testing.PB) {
@@ -91,8 +91,8 @@ grant_permissions:
   {{- end}}
   {{- end}}
 related_apps:
-  {{- if .RelatedApps}}
-- "{{index .RelatedApps 0}}"
+  {{- range .RelatedApps}}
+  - "{{.}}"
   {{- end}}
 resource_docs:
   {{- if .ResourceDocs.BaseDir}}
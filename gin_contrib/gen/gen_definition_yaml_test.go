@@ -12,6 +12,7 @@
 package gen
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/TencentBlueKing/bk-apigateway-sdks/gin_contrib/example/router"
@@ -137,3 +138,20 @@ func TestGenDefinitionConfigWithMcpServer(t *testing.T) {
 	definitionConfig := GenDefinitionYaml(config, "../example/docs/swagger.json", router.New())
 	t.Log(definitionConfig)
 }
+
+func TestGenDefinitionConfigRelatedApps(t *testing.T) {
+	config := &model.APIConfig{
+		Release: model.ReleaseConfig{Version: "1.0.0"},
+		Stage: &model.StageConfig{
+			Name:        "prod",
+			BackendHost: "http://api.example.com",
+		},
+		RelatedApps: []string{"app1", "app2"},
+	}
+
+	definitionConfig := GenDefinitionYaml(config, "../example/docs/swagger.json", router.New())
+	// 所有关联应用都应该被渲染
+	if !strings.Contains(definitionConfig, "related_apps:\n  - \"app1\"\n  - \"app2\"\n") {
+		t.Fatalf("related apps are not rendered: %s", definitionConfig)
+	}
+}
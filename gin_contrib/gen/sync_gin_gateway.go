@@ -12,6 +12,7 @@
 package gen
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -34,21 +35,35 @@ func SyncGinGateway(baseDir string, apiGatewayName string, config *model.APIConf
 		return
 	}
 
+	ctx := context.Background()
+
 	// 同步网关基础信息
-	info, err := defaultManager.SyncBasicInfo()
+	info, err := defaultManager.SyncBasicInfoContext(ctx)
 	if err != nil {
 		log.Fatalf("syncing gateway basic info: err:%v", err)
 		return
 	}
-	log.Printf("syncing gateway basic info success, info:%v\n", info)
+	log.Printf("syncing gateway basic info success, info:%+v\n", info.Raw)
+
+	// 同步网关关联应用
+	if len(config.RelatedApps) > 0 {
+		related, err := defaultManager.SyncRelatedAppsContext(ctx)
+		if err != nil {
+			log.Fatalf("syncing gateway related apps: err:%v", err)
+			return
+		}
+		log.Printf("syncing gateway related apps success, result:%+v\n", related.Raw)
+	}
 
 	// 同步网关环境信息
-	result, err := defaultManager.SyncStagesConfig()
+	stages, err := defaultManager.SyncStagesConfigContext(ctx)
 	if err != nil {
 		log.Fatalf("syncing gateway stage config: err:%v", err)
 		return
 	}
-	log.Printf("syncing gateway stage config success, result:%v\n", result)
+	for _, stage := range stages {
+		log.Printf("syncing gateway stage config success, stage:%s, result:%+v\n", stage.Name, stage.Raw)
+	}
 
 	// 同步网关资源信息
 	resourceFile, err := os.ReadFile(baseDir + "/resources.yaml")
@@ -58,7 +73,7 @@ func SyncGinGateway(baseDir string, apiGatewayName string, config *model.APIConf
 	}
 	log.Printf("call sync_apigw_resources with resources:%s\n", resourceFile)
 
-	result, err = defaultManager.SyncResourcesConfig(map[string]interface{}{
+	resources, err := defaultManager.SyncResourcesConfigContext(ctx, map[string]interface{}{
 		"content":  string(resourceFile),
 		"delete":   delete,
 		"language": config.ResourceDocs.Language,
@@ -67,65 +82,67 @@ func SyncGinGateway(baseDir string, apiGatewayName string, config *model.APIConf
 		log.Fatalf("syncing gateway resource config: err:%v", err)
 		return
 	}
-	log.Printf("syncing gateway resource config success, result:%v\n", result)
+	log.Printf("syncing gateway resource config success, added:%d, updated:%d, deleted:%d\n",
+		len(resources.Added), len(resources.Updated), len(resources.Deleted))
 
 	// 同步授权信息
-	result, err = defaultManager.GrantPermissions()
+	grants, err := defaultManager.GrantPermissionsContext(ctx)
 	if err != nil {
 		log.Fatalf("syncing gateway resource config: err:%v", err)
 		return
 	}
-	log.Printf("syncing gateway resource config success, result:%v\n", result)
+	for _, grant := range grants {
+		log.Printf("grant gateway permission success, app:%s, dimension:%s\n", grant.BkAppCode, grant.GrantDimension)
+	}
 
 	// 同步资源文档信息
 	if config.ResourceDocs.BaseDir != "" {
-		result, err = defaultManager.SyncResourceDocByArchive()
+		result, err := defaultManager.SyncResourceDocByArchiveContext(ctx)
 		if err != nil {
 			log.Fatalf("syncing gateway resource doc: err:%v", err)
 			return
 		}
-		log.Printf("syncing gateway resource doc success, result:%v\n", result)
+		log.Printf("syncing gateway resource doc success, result:%+v\n", result.Raw)
 	}
 
 	// 生成资源版本
-	versionInfo, err := defaultManager.GetLatestResourceVersion()
+	versionInfo, err := defaultManager.GetLatestResourceVersionContext(ctx)
 	if err != nil {
 		log.Fatalf("get  gateway resource version: err:%v", err)
 		return
 	}
-	fmt.Printf("gateway resource version:%+v\n", versionInfo)
+	fmt.Printf("gateway resource version:%+v\n", versionInfo.Raw)
 
 	newVersion := config.Release.Version
 
-	if len(versionInfo) > 0 {
-		oldVersion := versionInfo["version"].(string)
-		if strings.Contains(oldVersion, newVersion) {
-			newVersion = fmt.Sprintf("%s+%s", newVersion, time.Now().Format("20060102150405"))
-		}
+	if versionInfo.Version != "" && strings.Contains(versionInfo.Version, newVersion) {
+		newVersion = fmt.Sprintf("%s+%s", newVersion, time.Now().Format("20060102150405"))
 	}
-	result, err = defaultManager.CreateResourceVersion(newVersion, config.Release.Comment)
+	version, err := defaultManager.CreateResourceVersionContext(ctx, newVersion, config.Release.Comment)
 	if err != nil {
 		log.Fatalf("create gateway resource version: err:%v", err)
 		return
 	}
-	log.Printf("create gateway resource version success, result:%v\n", result)
+	log.Printf("create gateway resource version success, version:%s\n", version.Version)
 	// 发布资源版本
 	if !config.Release.NoPub {
-		result, err = defaultManager.Release(newVersion)
+		release, err := defaultManager.ReleaseContext(ctx, newVersion)
 		if err != nil {
 			log.Fatalf("release gateway resource version: err:%v", err)
 			return
 		}
-		log.Printf("release gateway resource version success, result:%v\n", result)
+		log.Printf("release gateway resource version success, stages:%v\n", release.StageNames)
 	}
 
 	if config.Stage.EnableMcpServers {
 		// 同步网关stage MCP Server 配置
-		result, err = defaultManager.SyncStageMcpConfig()
+		servers, err := defaultManager.SyncStageMcpConfigContext(ctx)
 		if err != nil {
 			log.Fatalf("syncing gateway stage config: err:%v", err)
 			return
 		}
-		log.Printf("syncing gateway stage config success, result:%v\n", result)
+		for _, server := range servers {
+			log.Printf("syncing gateway stage mcp config success, stage:%s, result:%+v\n", server.Name, server.Raw)
+		}
 	}
 }
@@ -0,0 +1,68 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+)
+
+const (
+	defaultBkTokenCookieName  = "bk_token"
+	defaultBkTicketCookieName = "bk_ticket"
+)
+
+// UserCredentialsConfig 用户凭证中间件配置，为空时使用默认值
+type UserCredentialsConfig struct {
+	// BkTokenCookieName bk_token 的 cookie 名称，默认为 bk_token
+	BkTokenCookieName string
+	// BkTicketCookieName bk_ticket 的 cookie 名称，默认为 bk_ticket
+	BkTicketCookieName string
+	// JWTHeaderName 网关 JWT 的请求头名称，默认为 X-Bkapi-Jwt
+	JWTHeaderName string
+}
+
+func (c *UserCredentialsConfig) setDefaults() {
+	if c.BkTokenCookieName == "" {
+		c.BkTokenCookieName = defaultBkTokenCookieName
+	}
+
+	if c.BkTicketCookieName == "" {
+		c.BkTicketCookieName = defaultBkTicketCookieName
+	}
+
+	if c.JWTHeaderName == "" {
+		c.JWTHeaderName = BkGatewayJWTHeaderKey
+	}
+}
+
+// UserCredentialsMiddleware 用户凭证中间件: 从请求的 cookie 和网关 JWT 中获取用户凭证，并保存到 c.Request.Context() 中；
+// 调用其他网关时，将该 context 通过 operation.SetContext 传入，并为客户端添加 bkapi.OptForwardUserCredentials 选项，
+// 用户凭证会被合并到 X-Bkapi-Authorization 请求头中
+func UserCredentialsMiddleware(config UserCredentialsConfig) func(c *gin.Context) {
+	config.setDefaults()
+
+	return func(c *gin.Context) {
+		credentials := bkapi.UserCredentials{
+			JWT: c.GetHeader(config.JWTHeaderName),
+		}
+		credentials.BkToken, _ = c.Cookie(config.BkTokenCookieName)
+		credentials.BkTicket, _ = c.Cookie(config.BkTicketCookieName)
+
+		if !credentials.IsEmpty() {
+			c.Request = c.Request.WithContext(bkapi.WithUserCredentials(c.Request.Context(), credentials))
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,89 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+)
+
+func TestUserCredentialsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		config   UserCredentialsConfig
+		cookies  []*http.Cookie
+		headers  map[string]string
+		expected *bkapi.UserCredentials
+	}{
+		{
+			name: "default names",
+			cookies: []*http.Cookie{
+				{Name: "bk_token", Value: "token"},
+				{Name: "bk_ticket", Value: "ticket"},
+			},
+			headers:  map[string]string{BkGatewayJWTHeaderKey: "jwt"},
+			expected: &bkapi.UserCredentials{BkToken: "token", BkTicket: "ticket", JWT: "jwt"},
+		},
+		{
+			name:     "custom cookie name",
+			config:   UserCredentialsConfig{BkTokenCookieName: "custom_token"},
+			cookies:  []*http.Cookie{{Name: "bk_token", Value: "ignored"}, {Name: "custom_token", Value: "token"}},
+			expected: &bkapi.UserCredentials{BkToken: "token"},
+		},
+		{
+			name:     "no credentials",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				credentials bkapi.UserCredentials
+				found       bool
+			)
+
+			engine := gin.New()
+			engine.Use(UserCredentialsMiddleware(tt.config))
+			engine.GET("/", func(c *gin.Context) {
+				credentials, found = bkapi.UserCredentialsFromContext(c.Request.Context())
+			})
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			for _, cookie := range tt.cookies {
+				request.AddCookie(cookie)
+			}
+			for key, value := range tt.headers {
+				request.Header.Set(key, value)
+			}
+			engine.ServeHTTP(httptest.NewRecorder(), request)
+
+			if tt.expected == nil {
+				if found {
+					t.Errorf("expected no credentials, got %+v", credentials)
+				}
+				return
+			}
+
+			if !found || credentials != *tt.expected {
+				t.Errorf("expected %+v, got %+v", *tt.expected, credentials)
+			}
+		})
+	}
+}
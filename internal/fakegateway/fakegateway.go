@@ -0,0 +1,121 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package fakegateway provides a local bk-apigateway for testing the manager and the command line tool.
+package fakegateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// Request is a request received by the gateway.
+type Request struct {
+	Method string
+	Path   string
+	Query  map[string]string
+	Body   map[string]interface{}
+}
+
+// Gateway replies the data by the method and path, it is safe to use concurrently.
+type Gateway struct {
+	*httptest.Server
+
+	lock     sync.Mutex
+	replies  map[string]interface{}
+	fallback interface{}
+	requests []Request
+}
+
+// New starts a gateway, the requests without a reply are responded 404.
+func New() *Gateway {
+	gateway := &Gateway{replies: make(map[string]interface{})}
+	gateway.Server = httptest.NewServer(http.HandlerFunc(gateway.serve))
+
+	return gateway
+}
+
+func (g *Gateway) serve(w http.ResponseWriter, r *http.Request) {
+	request := Request{Method: r.Method, Path: r.URL.Path, Query: make(map[string]string)}
+	for key := range r.URL.Query() {
+		request.Query[key] = r.URL.Query().Get(key)
+	}
+	_ = json.NewDecoder(r.Body).Decode(&request.Body)
+
+	g.lock.Lock()
+	g.requests = append(g.requests, request)
+	reply, ok := g.replies[r.Method+" "+r.URL.Path]
+	if !ok && g.fallback != nil {
+		reply, ok = map[string]interface{}{"code": 0, "data": g.fallback}, true
+	}
+	g.lock.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		reply = map[string]interface{}{"code": 40400, "message": "not found"}
+	}
+
+	_ = json.NewEncoder(w).Encode(reply)
+}
+
+// Reply sets the data replied to the requests of the method and path.
+func (g *Gateway) Reply(method, path string, data interface{}) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.replies[method+" "+path] = map[string]interface{}{"code": 0, "data": data}
+}
+
+// ReplyError sets the error replied to the requests of the method and path.
+func (g *Gateway) ReplyError(method, path string, code int, message string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.replies[method+" "+path] = map[string]interface{}{"code": code, "message": message}
+}
+
+// Fallback sets the data replied to the requests without a reply, instead of 404.
+func (g *Gateway) Fallback(data interface{}) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.fallback = data
+}
+
+// Requests returns the received requests in order.
+func (g *Gateway) Requests() []Request {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return append([]Request(nil), g.requests...)
+}
+
+// LastRequest returns the last received request.
+func (g *Gateway) LastRequest() Request {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return g.requests[len(g.requests)-1]
+}
+
+// Body returns the body of the last request of the method and path, nil if not requested.
+func (g *Gateway) Body(method, path string) map[string]interface{} {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	for i := len(g.requests) - 1; i >= 0; i-- {
+		if g.requests[i].Method == method && g.requests[i].Path == path {
+			return g.requests[i].Body
+		}
+	}
+
+	return nil
+}
@@ -12,10 +12,13 @@
 package manager
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
 	"strings"
 
 	pongo2 "github.com/flosch/pongo2/v5"
+	"github.com/pkg/errors"
 
 	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
 )
@@ -64,6 +67,85 @@ func (c *DefintionContext) Context(data interface{}) pongo2.Context {
 	}
 }
 
+// DefinitionTemplateError is the error of rendering the definition template, with the position in the template.
+type DefinitionTemplateError struct {
+	Path   string
+	Line   int
+	Column int
+	Err    error
+}
+
+// Error returns the error message with the position.
+func (e *DefinitionTemplateError) Error() string {
+	if e.Line <= 0 {
+		return fmt.Sprintf("%s: %v", e.Path, e.Err)
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %v", e.Path, e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the original error.
+func (e *DefinitionTemplateError) Unwrap() error {
+	return e.Err
+}
+
+// the definition is yaml instead of html, so the values should not be escaped,
+// the tags are on the first line to keep the line numbers of the errors.
+const (
+	definitionTemplatePrefix = "{% autoescape off %}"
+	definitionTemplateSuffix = "{% endautoescape %}"
+)
+
+var definitionTemplateSet = pongo2.NewSet("definition", pongo2.MustNewLocalFileSystemLoader(""))
+
+func newDefinitionTemplateError(path string, err error) error {
+	templateErr := &DefinitionTemplateError{Path: path, Err: err}
+
+	var pongoErr *pongo2.Error
+	if errors.As(err, &pongoErr) {
+		templateErr.Line = pongoErr.Line
+		templateErr.Column = pongoErr.Column
+		templateErr.Err = pongoErr.OrigError
+
+		// the prefix shifts the columns of the first line
+		if templateErr.Line == 1 && templateErr.Column > len(definitionTemplatePrefix) {
+			templateErr.Column -= len(definitionTemplatePrefix)
+		}
+	}
+
+	return templateErr
+}
+
+// RenderBytes renders the definition template with the data by the Django template syntax.
+func (c *DefintionContext) RenderBytes(path string, content []byte, data interface{}) ([]byte, error) {
+	source := make([]byte, 0, len(definitionTemplatePrefix)+len(content)+len(definitionTemplateSuffix))
+	source = append(source, definitionTemplatePrefix...)
+	source = append(source, content...)
+	source = append(source, definitionTemplateSuffix...)
+
+	template, err := definitionTemplateSet.FromBytes(source)
+	if err != nil {
+		return nil, newDefinitionTemplateError(path, err)
+	}
+
+	rendered, err := template.ExecuteBytes(c.Context(data))
+	if err != nil {
+		return nil, newDefinitionTemplateError(path, err)
+	}
+
+	return rendered, nil
+}
+
+// Render reads the definition template file and renders it with the data.
+func (c *DefintionContext) Render(path string, data interface{}) ([]byte, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	return c.RenderBytes(path, content, data)
+}
+
 // NewDefinitionContext return new definition context
 func NewDefinitionContext(apiName string, config *bkapi.ClientConfig) *DefintionContext {
 	return &DefintionContext{
@@ -0,0 +1,97 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package manager_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	manager "github.com/TencentBlueKing/bk-apigateway-sdks/manager"
+)
+
+var _ = Describe("Context", func() {
+	var (
+		context *manager.DefintionContext
+		path    string
+	)
+
+	BeforeEach(func() {
+		context = manager.NewDefinitionContext("testing", &bkapi.ClientConfig{AppCode: "app"})
+		path = filepath.Join(GinkgoT().TempDir(), "definition.yaml")
+	})
+
+	writeDefinition := func(content string) {
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+	}
+
+	It("should render the settings, environ and data", func() {
+		GinkgoT().Setenv("BK_TESTING_DEFINITION", "from-environ")
+		writeDefinition(`apigateway:
+  name: {{ settings.BK_APIGW_NAME }}
+  app: {{ settings.BK_APP_CODE }}
+  environ: {{ environ.BK_TESTING_DEFINITION }}
+  data: {{ data.key }}
+`)
+
+		rendered, err := context.Render(path, map[string]string{"key": "from-data"})
+		Expect(err).To(BeNil())
+		Expect(string(rendered)).To(Equal(`apigateway:
+  name: testing
+  app: app
+  environ: from-environ
+  data: from-data
+`))
+	})
+
+	It("should not escape the values", func() {
+		rendered, err := context.RenderBytes(path, []byte(`url: "{{ data }}"`), "http://example.com/?a=1&b=<2>")
+		Expect(err).To(BeNil())
+		Expect(string(rendered)).To(Equal(`url: "http://example.com/?a=1&b=<2>"`))
+	})
+
+	It("should return the error with the line number", func() {
+		writeDefinition("apigateway:\n  name: testing\n  description: {{ data|no_such_filter }}\n")
+
+		_, err := context.Render(path, nil)
+		Expect(err).NotTo(BeNil())
+
+		var templateErr *manager.DefinitionTemplateError
+		Expect(errors.As(err, &templateErr)).To(BeTrue())
+		Expect(templateErr.Path).To(Equal(path))
+		Expect(templateErr.Line).To(Equal(3))
+		Expect(err.Error()).To(HavePrefix(path + ":3:"))
+	})
+
+	It("should load the rendered definition by the manager", func() {
+		writeDefinition("apigateway:\n  description: {{ data.description }}\n")
+
+		m, err := manager.NewManagerFromWithData(
+			"testing", bkapi.ClientConfig{Endpoint: "http://example.com"}, path,
+			map[string]string{"description": "rendered"},
+		)
+		Expect(err).To(BeNil())
+
+		info, err := m.GetDefinition().Get("apigateway")
+		Expect(err).To(BeNil())
+		Expect(info["description"]).To(Equal("rendered"))
+
+		var buffer bytes.Buffer
+		Expect(m.GetDefinition().Dump(&buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal("apigateway:\n  description: rendered\n"))
+	})
+})
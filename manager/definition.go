@@ -13,6 +13,7 @@ package manager
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -22,6 +23,22 @@ import (
 // Definition represents a definition of a api gateway.
 type Definition struct {
 	definition map[string]interface{}
+	rendered   []byte
+}
+
+// Dump writes the rendered yaml of the definition, for inspecting the result of the template.
+func (d *Definition) Dump(writer io.Writer) error {
+	content := d.rendered
+	if content == nil {
+		var err error
+		content, err = yaml.Marshal(d.definition)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal yaml")
+		}
+	}
+
+	_, err := writer.Write(content)
+	return err
 }
 
 // Get sub definition.
@@ -45,12 +62,7 @@ func (d *Definition) Get(namespace string) (map[string]interface{}, error) {
 		case map[string]interface{}:
 			current = realValue
 		case map[interface{}]interface{}:
-			// convert map[interface{}]interface{} to map[string]interface{}
-			current = make(map[string]interface{})
-			for k, v := range realValue {
-				current[fmt.Sprintf("%v", k)] = v
-			}
-			return current, nil
+			current = stringKeyMap(realValue)
 		default:
 			return nil, errors.Wrapf(ErrNotFound, "namespace: %s", namespace)
 		}
@@ -72,10 +84,18 @@ func (d *Definition) GetArray(namespace string) ([]map[string]interface{}, error
 		}
 		switch realValue := value.(type) {
 		case []interface{}:
-			// convert []map[interface{}]interface{} to map[string]interface{}
 			result := make([]map[string]interface{}, len(realValue))
 			for i, v := range realValue {
-				result[i] = v.(map[string]interface{})
+				switch item := v.(type) {
+				case map[string]interface{}:
+					result[i] = item
+				case map[interface{}]interface{}:
+					result[i] = stringKeyMap(item)
+				default:
+					return nil, errors.Wrapf(
+						ErrDefinitionInvalid, "%s[%d] should be a mapping, but got %T", namespace, i, v,
+					)
+				}
 			}
 			return result, nil
 		default:
@@ -86,6 +106,53 @@ func (d *Definition) GetArray(namespace string) ([]map[string]interface{}, error
 	return []map[string]interface{}{}, nil
 }
 
+// GetStringArray Get sub string array definition, such as related_apps.
+func (d *Definition) GetStringArray(namespace string) ([]string, error) {
+	parent, field := "", namespace
+	if index := strings.LastIndex(namespace, "."); index >= 0 {
+		parent, field = namespace[:index], namespace[index+1:]
+	}
+
+	current, err := d.Get(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	value, found := current[field]
+	if !found {
+		return nil, errors.Wrapf(ErrNotFound, "namespace: %s", namespace)
+	}
+
+	switch realValue := value.(type) {
+	case nil:
+		return []string{}, nil
+	case []interface{}:
+		result := make([]string, len(realValue))
+		for i, v := range realValue {
+			item, ok := v.(string)
+			if !ok {
+				return nil, errors.Wrapf(
+					ErrDefinitionInvalid, "%s[%d] should be a string, but got %T", namespace, i, v,
+				)
+			}
+			result[i] = item
+		}
+		return result, nil
+	default:
+		return nil, errors.Wrapf(ErrDefinitionInvalid, "%s should be an array, but got %T", namespace, value)
+	}
+}
+
+// stringKeyMap converts map[interface{}]interface{} to map[string]interface{}.
+func stringKeyMap(value map[interface{}]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(value))
+	for k, v := range value {
+		result[fmt.Sprintf("%v", k)] = v
+	}
+
+	return result
+}
+
 // NewDefinition creates a new definition from the given map.
 func NewDefinition(definition map[string]interface{}) *Definition {
 	return &Definition{
@@ -101,5 +168,8 @@ func NewDefinitionFromYaml(content []byte) (*Definition, error) {
 		return nil, errors.Wrap(err, "failed to unmarshal yaml")
 	}
 
-	return NewDefinition(definition), nil
+	result := NewDefinition(definition)
+	result.rendered = content
+
+	return result, nil
 }
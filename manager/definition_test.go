@@ -12,6 +12,8 @@
 package manager_test
 
 import (
+	"bytes"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -58,4 +60,12 @@ var _ = Describe("Definition", func() {
 		Expect(err).To(BeNil())
 		Expect(sub["name"]).To(Equal("testing"))
 	})
+
+	It("should dump the definition as yaml", func() {
+		definition := manager.NewDefinition(map[string]interface{}{"sub": map[string]interface{}{"name": "testing"}})
+
+		var buffer bytes.Buffer
+		Expect(definition.Dump(&buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal("sub:\n    name: testing\n"))
+	})
 })
@@ -15,6 +15,7 @@ import "errors"
 
 var (
 	ErrNotFound                            = errors.New("not found")
+	ErrDefinitionInvalid                   = errors.New("definition invalid")
 	ErrApigatewayRequest                   = errors.New("apigateway request error")
 	ErrApiGatewayPublicKeyNotFound         = errors.New("public key not found")
 	ErrApiGatewayPublicKeyTypeNotSupported = errors.New("public key type not supported")
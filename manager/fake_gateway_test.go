@@ -0,0 +1,251 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package manager_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apigateway "github.com/TencentBlueKing/bk-apigateway-sdks/apigateway"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/internal/fakegateway"
+	manager "github.com/TencentBlueKing/bk-apigateway-sdks/manager"
+)
+
+var _ = Describe("FakeGateway", func() {
+	var (
+		gateway *fakegateway.Gateway
+		mgr     *manager.Manager
+		docsDir string
+	)
+
+	newManager := func(content string) *manager.Manager {
+		definition, err := manager.NewDefinitionFromYaml([]byte(content))
+		Expect(err).To(BeNil())
+
+		result, err := manager.NewManager(
+			"testing", bkapi.ClientConfig{Endpoint: gateway.URL}, definition,
+			func(configProvider define.ClientConfigProvider, opts ...define.BkApiClientOption) (*apigateway.Client, error) {
+				return apigateway.New(configProvider, opts...)
+			},
+		)
+		Expect(err).To(BeNil())
+
+		return result
+	}
+
+	BeforeEach(func() {
+		gateway = fakegateway.New()
+		docsDir = GinkgoT().TempDir()
+		swagger := filepath.Join(docsDir, "swagger.json")
+		Expect(os.WriteFile(swagger, []byte(`{"swagger": "2.0"}`), 0o644)).To(Succeed())
+
+		mgr = newManager(`spec_version: 2
+related_apps:
+  - app1
+  - app2
+resource_docs:
+  swagger: ` + swagger + `
+  language: en
+`)
+	})
+
+	AfterEach(func() {
+		gateway.Close()
+	})
+
+	Context("related apps", func() {
+		It("should add the related apps defined in the definition", func() {
+			gateway.Reply("POST", "/api/v1/apis/testing/related-apps/", map[string]interface{}{"count": 2})
+
+			result, err := mgr.SyncRelatedApps()
+			Expect(err).To(BeNil())
+			Expect(result.Raw).To(HaveKeyWithValue("count", float64(2)))
+			Expect(gateway.LastRequest().Body).To(HaveKeyWithValue(
+				"target_app_codes", []interface{}{"app1", "app2"},
+			))
+		})
+
+		It("should do nothing when no related app is defined", func() {
+			mgr = newManager("spec_version: 2\nrelated_apps:\n")
+
+			result, err := mgr.SyncRelatedApps()
+			Expect(err).To(BeNil())
+			Expect(result.Raw).To(BeNil())
+			Expect(len(gateway.Requests())).To(Equal(0))
+		})
+	})
+
+	It("should revoke the permissions", func() {
+		gateway.Reply("DELETE", "/api/v1/apis/testing/permissions/revoke/", map[string]interface{}{})
+
+		_, err := mgr.RevokePermissions([]string{"app1"}, "resource")
+		Expect(err).To(BeNil())
+
+		request := gateway.LastRequest()
+		Expect(request.Method).To(Equal("DELETE"))
+		Expect(request.Body).To(Equal(map[string]interface{}{
+			"target_app_codes": []interface{}{"app1"},
+			"grant_dimension":  "resource",
+		}))
+	})
+
+	It("should generate the sdks", func() {
+		gateway.Reply("POST", "/api/v1/apis/testing/sdk/", map[string]interface{}{"url": "http://sdk"})
+
+		result, err := mgr.GenerateSdk("1.0.0", []string{"python"})
+		Expect(err).To(BeNil())
+		Expect(result.Raw).To(HaveKeyWithValue("url", "http://sdk"))
+		Expect(gateway.LastRequest().Body).To(Equal(map[string]interface{}{
+			"resource_version": "1.0.0",
+			"languages":        []interface{}{"python"},
+		}))
+	})
+
+	It("should sync the resource docs by swagger", func() {
+		gateway.Reply("POST", "/api/v1/apis/testing/resource-docs/import/by-swagger/", map[string]interface{}{})
+
+		_, err := mgr.SyncResourceDocBySwagger()
+		Expect(err).To(BeNil())
+		Expect(gateway.LastRequest().Body).To(Equal(map[string]interface{}{
+			"swagger":  `{"swagger": "2.0"}`,
+			"language": "en",
+		}))
+	})
+
+	It("should return an error when the swagger is not defined", func() {
+		mgr = newManager("spec_version: 2\nresource_docs:\n  basedir: docs/\n")
+
+		_, err := mgr.SyncResourceDocBySwagger()
+		Expect(errors.Is(err, manager.ErrDefinitionInvalid)).To(BeTrue())
+	})
+
+	Context("queries", func() {
+		It("should get the apis", func() {
+			gateway.Reply("GET", "/api/v1/apis/", []interface{}{
+				map[string]interface{}{"id": 1, "name": "testing", "description": "desc", "is_public": true},
+			})
+
+			apis, err := mgr.GetApis(map[string]string{"name": "testing"})
+			Expect(err).To(BeNil())
+			Expect(apis).To(HaveLen(1))
+			Expect(apis[0].ID).To(Equal(int64(1)))
+			Expect(apis[0].Name).To(Equal("testing"))
+			Expect(apis[0].Raw).To(HaveKeyWithValue("is_public", true))
+			Expect(gateway.LastRequest().Query).To(HaveKeyWithValue("name", "testing"))
+		})
+
+		It("should get the stages", func() {
+			gateway.Reply("GET", "/api/v1/apis/testing/stages/", []interface{}{
+				map[string]interface{}{"id": 1, "name": "prod"},
+				map[string]interface{}{"id": 2, "name": "test"},
+			})
+
+			stages, err := mgr.GetStages()
+			Expect(err).To(BeNil())
+			Expect(stages).To(HaveLen(2))
+			Expect(stages[1].Name).To(Equal("test"))
+		})
+
+		It("should get the stages with resource version", func() {
+			gateway.Reply("GET", "/api/v1/apis/testing/stages/with-resource-version/", []interface{}{
+				map[string]interface{}{"name": "prod", "resource_version": map[string]interface{}{"version": "1.0.0"}},
+				map[string]interface{}{"name": "test", "resource_version": "0.1.0"},
+				map[string]interface{}{"name": "dev", "resource_version": nil},
+			})
+
+			stages, err := mgr.GetStagesWithResourceVersion()
+			Expect(err).To(BeNil())
+			Expect(stages).To(HaveLen(3))
+			Expect(stages[0].Version).To(Equal("1.0.0"))
+			Expect(stages[1].Version).To(Equal("0.1.0"))
+			Expect(stages[2].Version).To(BeEmpty())
+		})
+
+		It("should get the released resources", func() {
+			gateway.Reply("GET", "/api/v1/apis/testing/released/stages/prod/resources/", map[string]interface{}{
+				"count": 1,
+				"results": []interface{}{
+					map[string]interface{}{"id": 1, "name": "get_pet", "method": "GET", "path": "/pets/"},
+				},
+			})
+
+			resources, err := mgr.GetReleasedResources("prod")
+			Expect(err).To(BeNil())
+			Expect(resources).To(HaveLen(1))
+			Expect(resources[0].Method).To(Equal("GET"))
+			Expect(resources[0].Path).To(Equal("/pets/"))
+		})
+
+		It("should require the stage name", func() {
+			_, err := mgr.GetReleasedResources("")
+			Expect(errors.Is(err, define.ErrMissingPathParam)).To(BeTrue())
+		})
+	})
+
+	Context("micro gateway", func() {
+		const prefix = "/api/v1/edge-controller/micro-gateway/instance-1"
+
+		It("should get the micro gateway info", func() {
+			gateway.Reply("GET", prefix+"/gateway/", map[string]interface{}{"name": "testing"})
+
+			info, err := mgr.GetMicroGatewayInfo("instance-1")
+			Expect(err).To(BeNil())
+			Expect(info.Raw).To(HaveKeyWithValue("name", "testing"))
+		})
+
+		It("should get the permissions", func() {
+			permissions := []interface{}{map[string]interface{}{"bk_app_code": "app1"}}
+			gateway.Reply("GET", prefix+"/permissions/", permissions)
+			gateway.Reply("GET", prefix+"/permissions/gateway/newest/", permissions)
+			gateway.Reply("GET", prefix+"/permissions/resource/newest/", permissions)
+
+			for _, get := range []func(string, map[string]string) ([]*manager.Result, error){
+				mgr.GetMicroGatewayAppPermissions,
+				mgr.GetMicroGatewayNewestGatewayPermissions,
+				mgr.GetMicroGatewayNewestResourcePermissions,
+			} {
+				results, err := get("instance-1", map[string]string{"bk_app_code": "app1"})
+				Expect(err).To(BeNil())
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Raw).To(HaveKeyWithValue("bk_app_code", "app1"))
+				Expect(gateway.LastRequest().Query).To(HaveKeyWithValue("bk_app_code", "app1"))
+			}
+		})
+
+		It("should update the status", func() {
+			gateway.Reply("PUT", prefix+"/status/", map[string]interface{}{})
+
+			_, err := mgr.UpdateMicroGatewayStatus("instance-1", map[string]interface{}{"status": "running"})
+			Expect(err).To(BeNil())
+
+			request := gateway.LastRequest()
+			Expect(request.Method).To(Equal("PUT"))
+			Expect(request.Body).To(HaveKeyWithValue("status", "running"))
+		})
+
+		It("should require the instance id", func() {
+			_, err := mgr.GetMicroGatewayInfo("")
+			Expect(errors.Is(err, define.ErrMissingPathParam)).To(BeTrue())
+		})
+	})
+
+	It("should return the error of the gateway", func() {
+		_, err := mgr.GetStages()
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -12,8 +12,9 @@
 package manager
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"os"
 
 	"github.com/pkg/errors"
@@ -29,6 +30,10 @@ const (
 	stagesNamespace       = "stages"
 	permissionsNamespace  = "grant_permissions"
 	resourceDocsNamespace = "resource_docs"
+	relatedAppsNamespace  = "related_apps"
+
+	// defaultResourceDocLanguage is the language of the swagger docs if it is not defined.
+	defaultResourceDocLanguage = "zh"
 )
 
 type apiGatewayResult struct {
@@ -47,30 +52,34 @@ type Manager struct {
 }
 
 func (m *Manager) requestWithBody(
+	ctx context.Context,
 	operation define.Operation,
 	body map[string]interface{},
 ) (map[string]interface{}, error) {
-	return m.request(operation.SetBody(body))
+	return m.request(ctx, operation.SetBody(body))
 }
 
 func (m *Manager) requestWithBodyV2(
+	ctx context.Context,
 	operation define.Operation,
 	body map[string]interface{},
 ) (map[string]interface{}, error) {
-	return m.requestV2(operation.SetBody(body))
+	return m.requestV2(ctx, operation.SetBody(body))
 }
 
 func (m *Manager) requestWithFile(
+	ctx context.Context,
 	operation define.Operation,
 	name string,
 	file *os.File,
 ) (map[string]interface{}, error) {
-	return m.request(operation.SetFile(name, file))
+	return m.request(ctx, operation.SetFile(name, file))
 }
 
-func (m *Manager) request(operation define.Operation) (map[string]interface{}, error) {
+func (m *Manager) request(ctx context.Context, operation define.Operation) (map[string]interface{}, error) {
 	var result apiGatewayResult
 	_, err := operation.
+		SetContext(ctx).
 		SetPathParams(map[string]string{
 			"api_name": m.apiName,
 		}).
@@ -92,9 +101,10 @@ func (m *Manager) request(operation define.Operation) (map[string]interface{}, e
 	)
 }
 
-func (m *Manager) requestV2(operation define.Operation) (map[string]interface{}, error) {
+func (m *Manager) requestV2(ctx context.Context, operation define.Operation) (map[string]interface{}, error) {
 	var result apiGatewayResult
 	_, err := operation.
+		SetContext(ctx).
 		SetPathParams(map[string]string{
 			"gateway_name": m.apiName,
 		}).
@@ -116,11 +126,75 @@ func (m *Manager) requestV2(operation define.Operation) (map[string]interface{},
 	)
 }
 
-// LoadDefinition will load the definition from the file.
+// requestData requests the operation and decodes the data of the result into the value.
+func (m *Manager) requestData(ctx context.Context, operation define.Operation, value interface{}) error {
+	var result struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	_, err := operation.
+		SetContext(ctx).
+		SetPathParams(map[string]string{
+			"api_name": m.apiName,
+		}).
+		SetResult(&result).
+		Request()
+	if err != nil {
+		return errors.Wrapf(err, "request to %v failed", operation)
+	}
+
+	if result.Code != 0 {
+		return errors.Wrapf(ErrApigatewayRequest, "code: %d, message: %s", result.Code, result.Message)
+	}
+
+	if len(result.Data) == 0 || string(result.Data) == "null" {
+		return nil
+	}
+
+	return errors.Wrapf(json.Unmarshal(result.Data, value), "failed to decode the data of %v", operation)
+}
+
+// requestList requests the operation whose data is a list, or a paginated object with results.
+func (m *Manager) requestList(ctx context.Context, operation define.Operation) ([]map[string]interface{}, error) {
+	var data interface{}
+	err := m.requestData(ctx, operation, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	if paginated, ok := data.(map[string]interface{}); ok {
+		data = paginated["results"]
+	}
+
+	items, _ := data.([]interface{})
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if value, ok := item.(map[string]interface{}); ok {
+			result = append(result, value)
+		}
+	}
+
+	return result, nil
+}
+
+// LoadDefinition will load the definition from the file, which is rendered as a template.
 func (m *Manager) LoadDefinition(path string) error {
-	rendered, err := ioutil.ReadFile(path)
+	return m.LoadDefinitionWithData(path, nil)
+}
+
+// LoadDefinitionWithData will render the definition file with the data, and load the rendered definition.
+// The template can refer to settings, environ and data, see DefintionContext.
+func (m *Manager) LoadDefinitionWithData(path string, data interface{}) error {
+	// fill the app code and secret from the environment variables
+	config, ok := m.config.ProvideConfig(m.apiName).(*bkapi.ClientConfig)
+	if !ok {
+		config = m.config
+	}
+
+	rendered, err := NewDefinitionContext(m.apiName, config).Render(path, data)
 	if err != nil {
-		return errors.Wrapf(err, "failed to read %s", path)
+		return err
 	}
 	definition, err := NewDefinitionFromYaml(rendered)
 	if err != nil {
@@ -137,18 +211,41 @@ func (m *Manager) GetDefinition() *Definition {
 }
 
 // GetPublicKey fetch the public key info from apigw.
+// Use GetPublicKeyContext for the typed result and a context to cancel the requests.
 func (m *Manager) GetPublicKey() (map[string]interface{}, error) {
-	return m.request(m.client.GetApigwPublicKey())
+	result, err := m.GetPublicKeyContext(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Raw, nil
 }
 
-// GetPublicKey fetch the public key from apigw.
+// GetPublicKeyContext fetch the public key info from apigw, and returns the typed result.
+func (m *Manager) GetPublicKeyContext(ctx context.Context) (*PublicKeyInfo, error) {
+	data, err := m.request(ctx, m.client.GetApigwPublicKey())
+	if err != nil {
+		return nil, err
+	}
+
+	info := &PublicKeyInfo{Raw: data}
+	decodeResult(data, info)
+	return info, nil
+}
+
+// GetPublicKeyString fetch the public key from apigw.
 func (m *Manager) GetPublicKeyString() (string, error) {
-	info, err := m.GetPublicKey()
+	return m.GetPublicKeyStringContext(context.Background())
+}
+
+// GetPublicKeyStringContext is GetPublicKeyString with a context to cancel the requests.
+func (m *Manager) GetPublicKeyStringContext(ctx context.Context) (string, error) {
+	info, err := m.GetPublicKeyContext(ctx)
 	if err != nil {
 		return "", err
 	}
 
-	value, ok := info["public_key"]
+	value, ok := info.Raw["public_key"]
 	if !ok {
 		return "", errors.Wrap(ErrApiGatewayPublicKeyNotFound, m.apiName)
 	}
@@ -164,108 +261,285 @@ func (m *Manager) GetPublicKeyString() (string, error) {
 	return publicKey, nil
 }
 
-// GetLatestResourceVersion get the latest resource version from apigw.
+// GetLatestResourceVersion get the latest resource version from apigw,
+// the version is empty if no resource version has been created.
+// Use GetLatestResourceVersionContext for the typed result and a context to cancel the requests.
 func (m *Manager) GetLatestResourceVersion() (map[string]interface{}, error) {
-	return m.request(m.client.GetLatestResourceVersion())
+	result, err := m.GetLatestResourceVersionContext(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Raw, nil
+}
+
+// GetLatestResourceVersionContext get the latest resource version from apigw,
+// the version is empty if no resource version has been created.
+func (m *Manager) GetLatestResourceVersionContext(ctx context.Context) (*ResourceVersion, error) {
+	data, err := m.request(ctx, m.client.GetLatestResourceVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	version := &ResourceVersion{Raw: data}
+	decodeResult(data, version)
+	return version, nil
 }
 
 // SyncBasicInfo sync the basic info from definition under the namespace to apigw.
+// Use SyncBasicInfoContext for the typed result and a context to cancel the requests.
 func (m *Manager) SyncBasicInfo() (map[string]interface{}, error) {
+	result, err := m.SyncBasicInfoContext(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Raw, nil
+}
+
+// SyncBasicInfoContext sync the basic info from definition under the namespace to apigw.
+func (m *Manager) SyncBasicInfoContext(ctx context.Context) (*SyncAPIResult, error) {
 	data, err := m.definition.Get(apiGatewayNamespace)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to get %s", apiGatewayNamespace)
 	}
 
-	return m.requestWithBody(m.client.SyncAPI(), data)
+	raw, err := m.requestWithBody(ctx, m.client.SyncAPI(), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncAPIResult{Raw: raw}
+	decodeResult(raw, result)
+	return result, nil
 }
 
 // SyncStagesConfig sync the stages config from definition under the namespace to apigw.
+// The results are keyed by the stage names.
+// Use SyncStagesConfigContext for the typed results and a context to cancel the requests.
 func (m *Manager) SyncStagesConfig() (map[string]interface{}, error) {
+	results, err := m.SyncStagesConfigContext(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	resultMap := make(map[string]interface{}, len(results))
+	for _, result := range results {
+		resultMap[result.Name] = result.Raw
+	}
+	return resultMap, nil
+}
+
+// SyncStagesConfigContext sync the stages config from definition under the namespace to apigw,
+// the results are in the order of the stages.
+func (m *Manager) SyncStagesConfigContext(ctx context.Context) ([]*StageSyncResult, error) {
 	stages, err := m.definition.GetArray(stagesNamespace)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to get %s", stagesNamespace)
 	}
-	resultMap := make(map[string]interface{})
+	results := make([]*StageSyncResult, 0, len(stages))
 	for _, stage := range stages {
-		result, err := m.requestWithBody(m.client.SyncStage(), stage)
+		raw, err := m.requestWithBody(ctx, m.client.SyncStage(), stage)
 		if err != nil {
-			return nil, errors.WithMessagef(err, "failed to get %s", stagesNamespace)
+			return nil, errors.WithMessagef(err, "failed to sync stage %v", stage["name"])
 		}
-		resultMap[fmt.Sprintf("%v", stage["name"])] = result
+
+		results = append(results, newStageSyncResult(stage, raw))
 	}
-	return resultMap, nil
+	return results, nil
 }
 
 // SyncStageMcpConfig sync mcp config from definition under the namespace to apigw.
+// The results are keyed by the stage names.
+// Use SyncStageMcpConfigContext for the typed results and a context to cancel the requests.
 func (m *Manager) SyncStageMcpConfig() (map[string]interface{}, error) {
+	results, err := m.SyncStageMcpConfigContext(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	resultMap := make(map[string]interface{}, len(results))
+	for _, result := range results {
+		resultMap[result.Name] = result.Raw
+	}
+	return resultMap, nil
+}
+
+// SyncStageMcpConfigContext sync mcp config from definition under the namespace to apigw,
+// the results are in the order of the stages.
+func (m *Manager) SyncStageMcpConfigContext(ctx context.Context) ([]*StageSyncResult, error) {
 	stages, err := m.definition.GetArray(stagesNamespace)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to get %s", stagesNamespace)
 	}
-	resultMap := make(map[string]interface{})
+	results := make([]*StageSyncResult, 0, len(stages))
 	for _, stage := range stages {
-		result, err := m.requestWithBodyV2(m.client.SyncStageMcpServers().SetPathParams(
+		raw, err := m.requestWithBodyV2(ctx, m.client.SyncStageMcpServers().SetPathParams(
 			map[string]string{"stage_name": fmt.Sprintf("%v", stage["name"])}), stage)
 		if err != nil {
-			return nil, errors.WithMessagef(err, "failed to get %s", stagesNamespace)
+			return nil, errors.WithMessagef(err, "failed to sync mcp servers of stage %v", stage["name"])
 		}
-		resultMap[fmt.Sprintf("%v", stage["name"])] = result
+
+		results = append(results, newStageSyncResult(stage, raw))
 	}
-	return resultMap, nil
+	return results, nil
+}
+
+// newStageSyncResult decodes the result of the stage, the name defaults to the one in the definition.
+func newStageSyncResult(stage map[string]interface{}, raw map[string]interface{}) *StageSyncResult {
+	result := &StageSyncResult{Raw: raw}
+	decodeResult(raw, result)
+
+	if result.Name == "" {
+		result.Name = fmt.Sprintf("%v", stage["name"])
+	}
+
+	return result
 }
 
 // SyncPluginConfig sync the plugin config from definition under the namespace to apigw.
+// Use SyncPluginConfigContext for the typed result and a context to cancel the requests.
 func (m *Manager) SyncPluginConfig(namespace string) (map[string]interface{}, error) {
+	result, err := m.SyncPluginConfigContext(context.Background(), namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Raw, nil
+}
+
+// SyncPluginConfigContext sync the plugin config from definition under the namespace to apigw.
+func (m *Manager) SyncPluginConfigContext(ctx context.Context, namespace string) (*Result, error) {
 	data, err := m.definition.Get(namespace)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to get %s", namespace)
 	}
 
-	return m.requestWithBody(m.client.SyncAccessStrategy(), data)
+	raw, err := m.requestWithBody(ctx, m.client.SyncAccessStrategy(), data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Raw: raw}, nil
 }
 
 // SyncResourcesConfig sync the resources config from definition under the namespace to apigw.
+// Use SyncResourcesConfigContext for the typed result and a context to cancel the requests.
 func (m *Manager) SyncResourcesConfig(resources map[string]interface{}) (map[string]interface{}, error) {
-	return m.requestWithBody(m.client.SyncResources(), resources)
+	result, err := m.SyncResourcesConfigContext(context.Background(), resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Raw, nil
+}
+
+// SyncResourcesConfigContext sync the resources config from definition under the namespace to apigw.
+func (m *Manager) SyncResourcesConfigContext(
+	ctx context.Context,
+	resources map[string]interface{},
+) (*ResourceSyncResult, error) {
+	raw, err := m.requestWithBody(ctx, m.client.SyncResources(), resources)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ResourceSyncResult{Raw: raw}
+	decodeResult(raw, result)
+	return result, nil
 }
 
 // SyncResourceDocByArchive sync the resource doc from archive to apigw.
+// Use SyncResourceDocByArchiveContext for the typed result and a context to cancel the requests.
 func (m *Manager) SyncResourceDocByArchive() (map[string]interface{}, error) {
+	result, err := m.SyncResourceDocByArchiveContext(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Raw, nil
+}
+
+// SyncResourceDocByArchiveContext sync the resource doc from archive to apigw.
+func (m *Manager) SyncResourceDocByArchiveContext(ctx context.Context) (*Result, error) {
 	data, err := m.definition.Get(resourceDocsNamespace)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to get %s", resourceDocsNamespace)
 	}
-	baseDir := data["basedir"].(string)
+	baseDir, ok := data["basedir"].(string)
+	if !ok || baseDir == "" {
+		return nil, errors.Wrapf(ErrDefinitionInvalid, "%s.basedir must be a non-empty string", resourceDocsNamespace)
+	}
 	err = util.ZipDirectory(baseDir, baseDir+"resources_docs.zip", ".md")
 	if err != nil {
-		return nil, errors.WithMessagef(err, "failed to zip %s", data["base_dir"].(string))
+		return nil, errors.WithMessagef(err, "failed to zip %s", baseDir)
 	}
 	// 上传资源文档
 	resourceDocsFile, err := os.Open(baseDir + "/resources_docs.zip")
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to read %s", baseDir+"/resources_docs.zip")
 	}
-	return m.requestWithFile(m.client.ImportResourceDocsByArchive(), "file", resourceDocsFile)
+	defer resourceDocsFile.Close()
+
+	raw, err := m.requestWithFile(ctx, m.client.ImportResourceDocsByArchive(), "file", resourceDocsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Raw: raw}, nil
 }
 
 // ApplyPermissions apply the permissions under the namespace to apigw.
+// Use ApplyPermissionsContext for the typed result and a context to cancel the requests.
 func (m *Manager) ApplyPermissions(namespace string) (map[string]interface{}, error) {
+	result, err := m.ApplyPermissionsContext(context.Background(), namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Raw, nil
+}
+
+// ApplyPermissionsContext apply the permissions under the namespace to apigw.
+func (m *Manager) ApplyPermissionsContext(ctx context.Context, namespace string) (*Result, error) {
 	data, err := m.definition.Get(namespace)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to get %s", namespace)
 	}
 
-	return m.requestWithBody(m.client.ApplyPermissions(), data)
+	raw, err := m.requestWithBody(ctx, m.client.ApplyPermissions(), data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Raw: raw}, nil
 }
 
 // GrantPermissions grant the permissions under the namespace to apigw.
+// The results are keyed by result_<index of the permission>.
+// Use GrantPermissionsContext for the typed results and a context to cancel the requests.
 func (m *Manager) GrantPermissions() (map[string]interface{}, error) {
+	results, err := m.GrantPermissionsContext(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	resultMap := make(map[string]interface{}, len(results))
+	for i, result := range results {
+		resultMap[fmt.Sprintf("result_%d", i)] = result.Raw
+	}
+	return resultMap, nil
+}
+
+// GrantPermissionsContext grant the permissions under the namespace to apigw,
+// the results are in the order of the permissions.
+func (m *Manager) GrantPermissionsContext(ctx context.Context) ([]*GrantResult, error) {
 	datas, err := m.definition.GetArray(permissionsNamespace)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to get %s", permissionsNamespace)
 	}
-	resultMap := make(map[string]interface{})
-	for i, data := range datas {
+	results := make([]*GrantResult, 0, len(datas))
+	for _, data := range datas {
 		param := map[string]interface{}{
 			"target_app_code": data["bk_app_code"],
 			"grant_dimension": data["grant_dimension"],
@@ -274,39 +548,195 @@ func (m *Manager) GrantPermissions() (map[string]interface{}, error) {
 		if ok {
 			param["resource_names"] = resourceNames
 		}
-		result, err := m.requestWithBody(m.client.GrantPermissions(), param)
+		raw, err := m.requestWithBody(ctx, m.client.GrantPermissions(), param)
 		if err != nil {
 			return nil, err
 		}
-		resultMap[fmt.Sprintf("result_%d", i)] = result
+
+		result := &GrantResult{Raw: raw}
+		result.BkAppCode, _ = data["bk_app_code"].(string)
+		result.GrantDimension, _ = data["grant_dimension"].(string)
+		results = append(results, result)
 	}
-	return resultMap, nil
+	return results, nil
+}
+
+// SyncRelatedApps adds the apps defined in related_apps as the related apps of the gateway,
+// which can manage the gateway too. Nothing is requested if no app is defined.
+func (m *Manager) SyncRelatedApps() (*Result, error) {
+	return m.SyncRelatedAppsContext(context.Background())
+}
+
+// SyncRelatedAppsContext is SyncRelatedApps with a context to cancel the requests.
+func (m *Manager) SyncRelatedAppsContext(ctx context.Context) (*Result, error) {
+	apps, err := m.definition.GetStringArray(relatedAppsNamespace)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, errors.WithMessagef(err, "failed to get %s", relatedAppsNamespace)
+	}
+	if len(apps) == 0 {
+		return &Result{}, nil
+	}
+
+	raw, err := m.requestWithBody(ctx, m.client.AddRelatedApps(), map[string]interface{}{
+		"target_app_codes": apps,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Raw: raw}, nil
+}
+
+// RevokePermissions revoke the permissions of the apps, the grant dimension is api or resource,
+// empty means all of them.
+func (m *Manager) RevokePermissions(appCodes []string, grantDimension string) (*Result, error) {
+	return m.RevokePermissionsContext(context.Background(), appCodes, grantDimension)
+}
+
+// RevokePermissionsContext is RevokePermissions with a context to cancel the requests.
+func (m *Manager) RevokePermissionsContext(
+	ctx context.Context,
+	appCodes []string,
+	grantDimension string,
+) (*Result, error) {
+	data := map[string]interface{}{
+		"target_app_codes": appCodes,
+	}
+	if grantDimension != "" {
+		data["grant_dimension"] = grantDimension
+	}
+
+	raw, err := m.requestWithBody(ctx, m.client.RevokePermissions(), data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Raw: raw}, nil
+}
+
+// GenerateSdk generate the sdks of the resource version in the languages, such as python.
+func (m *Manager) GenerateSdk(version string, languages []string) (*Result, error) {
+	return m.GenerateSdkContext(context.Background(), version, languages)
+}
+
+// GenerateSdkContext is GenerateSdk with a context to cancel the requests.
+func (m *Manager) GenerateSdkContext(ctx context.Context, version string, languages []string) (*Result, error) {
+	raw, err := m.requestWithBody(ctx, m.client.GenerateSdk(), map[string]interface{}{
+		"resource_version": version,
+		"languages":        languages,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Raw: raw}, nil
+}
+
+// SyncResourceDocBySwagger sync the resource docs from the swagger file defined in resource_docs.swagger,
+// the language is defined in resource_docs.language, defaults to zh.
+func (m *Manager) SyncResourceDocBySwagger() (*Result, error) {
+	return m.SyncResourceDocBySwaggerContext(context.Background())
+}
+
+// SyncResourceDocBySwaggerContext is SyncResourceDocBySwagger with a context to cancel the requests.
+func (m *Manager) SyncResourceDocBySwaggerContext(ctx context.Context) (*Result, error) {
+	data, err := m.definition.Get(resourceDocsNamespace)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get %s", resourceDocsNamespace)
+	}
+	swaggerPath, ok := data["swagger"].(string)
+	if !ok || swaggerPath == "" {
+		return nil, errors.Wrapf(ErrDefinitionInvalid, "%s.swagger must be a non-empty string", resourceDocsNamespace)
+	}
+	language, _ := data["language"].(string)
+	if language == "" {
+		language = defaultResourceDocLanguage
+	}
+
+	swagger, err := os.ReadFile(swaggerPath)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read %s", swaggerPath)
+	}
+
+	raw, err := m.requestWithBody(ctx, m.client.ImportResourceDocsBySwagger(), map[string]interface{}{
+		"swagger":  string(swagger),
+		"language": language,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Raw: raw}, nil
 }
 
 // CreateResourceVersion create a resource version defined in the namespace.
+// Use CreateResourceVersionContext for the typed result and a context to cancel the requests.
 func (m *Manager) CreateResourceVersion(version string, comment string) (map[string]interface{}, error) {
+	result, err := m.CreateResourceVersionContext(context.Background(), version, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Raw, nil
+}
+
+// CreateResourceVersionContext create a resource version defined in the namespace.
+func (m *Manager) CreateResourceVersionContext(
+	ctx context.Context,
+	version string,
+	comment string,
+) (*ResourceVersion, error) {
 	data := map[string]interface{}{
 		"version": version,
 		"comment": comment,
 	}
-	return m.requestWithBody(m.client.CreateResourceVersion(), data)
+	raw, err := m.requestWithBody(ctx, m.client.CreateResourceVersion(), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ResourceVersion{Version: version, Comment: comment, Raw: raw}
+	decodeResult(raw, result)
+	return result, nil
 }
 
 // Release release the resource version defined in the namespace.
+// Use ReleaseContext for the typed result and a context to cancel the requests.
 func (m *Manager) Release(version string) (map[string]interface{}, error) {
+	result, err := m.ReleaseContext(context.Background(), version)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Raw, nil
+}
+
+// ReleaseContext release the resource version defined in the namespace.
+func (m *Manager) ReleaseContext(ctx context.Context, version string) (*ReleaseResult, error) {
 	stages, err := m.definition.GetArray(stagesNamespace)
 	if err != nil {
-		return nil, errors.WithMessagef(err, "failed to get %s", permissionsNamespace)
+		return nil, errors.WithMessagef(err, "failed to get %s", stagesNamespace)
 	}
 	var stageNames []string
-	for _, stage := range stages {
-		stageNames = append(stageNames, stage["name"].(string))
+	for i, stage := range stages {
+		name, ok := stage["name"].(string)
+		if !ok || name == "" {
+			return nil, errors.Wrapf(ErrDefinitionInvalid, "%s[%d].name must be a non-empty string", stagesNamespace, i)
+		}
+		stageNames = append(stageNames, name)
 	}
 	data := map[string]interface{}{
 		"stage_names": stageNames,
 		"version":     version,
 	}
-	return m.requestWithBody(m.client.Release(), data)
+	raw, err := m.requestWithBody(ctx, m.client.Release(), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReleaseResult{Version: version, StageNames: stageNames, Raw: raw}
+	decodeResult(raw, result)
+	return result, nil
 }
 
 // NewManager create a new manager.
@@ -349,3 +779,18 @@ func NewManagerFrom(
 
 	return manager, manager.LoadDefinition(path)
 }
+
+// NewManagerFromWithData will create a new manager from the file rendered with the data.
+func NewManagerFromWithData(
+	apiName string,
+	config bkapi.ClientConfig,
+	path string,
+	data interface{},
+) (*Manager, error) {
+	manager, err := NewDefaultManager(apiName, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create manager")
+	}
+
+	return manager, manager.LoadDefinitionWithData(path, data)
+}
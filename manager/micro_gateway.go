@@ -0,0 +1,146 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package manager
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+// microGatewayOperation sets the micro gateway instance of the operation.
+func microGatewayOperation(operation define.Operation, instanceID string) (define.Operation, error) {
+	if instanceID == "" {
+		return nil, errors.Wrap(define.ErrMissingPathParam, "instance_id")
+	}
+
+	return operation.SetPathParams(map[string]string{"instance_id": instanceID}), nil
+}
+
+// GetMicroGatewayInfo fetch the gateway info of the micro gateway instance from apigw.
+func (m *Manager) GetMicroGatewayInfo(instanceID string) (*Result, error) {
+	return m.GetMicroGatewayInfoContext(context.Background(), instanceID)
+}
+
+// GetMicroGatewayInfoContext is GetMicroGatewayInfo with a context to cancel the requests.
+func (m *Manager) GetMicroGatewayInfoContext(ctx context.Context, instanceID string) (*Result, error) {
+	operation, err := microGatewayOperation(m.client.GetMicroGatewayInfo(), instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := m.request(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Raw: raw}, nil
+}
+
+// GetMicroGatewayAppPermissions fetch the app permissions of the micro gateway instance from apigw.
+func (m *Manager) GetMicroGatewayAppPermissions(instanceID string, query map[string]string) ([]*Result, error) {
+	return m.GetMicroGatewayAppPermissionsContext(context.Background(), instanceID, query)
+}
+
+// GetMicroGatewayAppPermissionsContext is GetMicroGatewayAppPermissions with a context to cancel the requests.
+func (m *Manager) GetMicroGatewayAppPermissionsContext(
+	ctx context.Context,
+	instanceID string,
+	query map[string]string,
+) ([]*Result, error) {
+	return m.requestMicroGatewayPermissions(ctx, m.client.GetMicroGatewayAppPermissions(), instanceID, query)
+}
+
+// GetMicroGatewayNewestGatewayPermissions fetch the newest gateway permissions of the micro gateway instance.
+func (m *Manager) GetMicroGatewayNewestGatewayPermissions(
+	instanceID string,
+	query map[string]string,
+) ([]*Result, error) {
+	return m.GetMicroGatewayNewestGatewayPermissionsContext(context.Background(), instanceID, query)
+}
+
+// GetMicroGatewayNewestGatewayPermissionsContext is GetMicroGatewayNewestGatewayPermissions with a context.
+func (m *Manager) GetMicroGatewayNewestGatewayPermissionsContext(
+	ctx context.Context,
+	instanceID string,
+	query map[string]string,
+) ([]*Result, error) {
+	return m.requestMicroGatewayPermissions(
+		ctx, m.client.GetMicroGatewayNewestGatewayPermissions(), instanceID, query,
+	)
+}
+
+// GetMicroGatewayNewestResourcePermissions fetch the newest resource permissions of the micro gateway instance.
+func (m *Manager) GetMicroGatewayNewestResourcePermissions(
+	instanceID string,
+	query map[string]string,
+) ([]*Result, error) {
+	return m.GetMicroGatewayNewestResourcePermissionsContext(context.Background(), instanceID, query)
+}
+
+// GetMicroGatewayNewestResourcePermissionsContext is GetMicroGatewayNewestResourcePermissions with a context.
+func (m *Manager) GetMicroGatewayNewestResourcePermissionsContext(
+	ctx context.Context,
+	instanceID string,
+	query map[string]string,
+) ([]*Result, error) {
+	return m.requestMicroGatewayPermissions(
+		ctx, m.client.GetMicroGatewayNewestResourcePermissions(), instanceID, query,
+	)
+}
+
+func (m *Manager) requestMicroGatewayPermissions(
+	ctx context.Context,
+	operation define.Operation,
+	instanceID string,
+	query map[string]string,
+) ([]*Result, error) {
+	operation, err := microGatewayOperation(operation, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := m.requestList(ctx, operation.SetQueryParams(query))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResults(items, func(result *Result, raw map[string]interface{}) {
+		result.Raw = raw
+	}), nil
+}
+
+// UpdateMicroGatewayStatus reports the status of the micro gateway instance to apigw.
+func (m *Manager) UpdateMicroGatewayStatus(instanceID string, status map[string]interface{}) (*Result, error) {
+	return m.UpdateMicroGatewayStatusContext(context.Background(), instanceID, status)
+}
+
+// UpdateMicroGatewayStatusContext is UpdateMicroGatewayStatus with a context to cancel the requests.
+func (m *Manager) UpdateMicroGatewayStatusContext(
+	ctx context.Context,
+	instanceID string,
+	status map[string]interface{},
+) (*Result, error) {
+	operation, err := microGatewayOperation(m.client.UpdateMicroGatewayStatus(), instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := m.requestWithBody(ctx, operation, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Raw: raw}, nil
+}
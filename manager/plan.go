@@ -0,0 +1,547 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// PlanAction is the action to take on an object when syncing.
+type PlanAction string
+
+const (
+	// PlanActionAdd indicates the object only exists in local.
+	PlanActionAdd PlanAction = "add"
+	// PlanActionRemove indicates the object only exists in the gateway.
+	PlanActionRemove PlanAction = "remove"
+	// PlanActionChange indicates the object exists in both sides, but some fields are different.
+	PlanActionChange PlanAction = "change"
+	// PlanActionGrant indicates the permission will be granted, permissions can not be read from the gateway,
+	// so they are always listed and are not counted as changes.
+	PlanActionGrant PlanAction = "grant"
+	// PlanActionUnmanaged indicates the object only exists in the gateway, but the sync never deletes it,
+	// such as the stages, so it is listed for information and is not counted as a change.
+	PlanActionUnmanaged PlanAction = "unmanaged"
+)
+
+// PlanKind is the kind of the changed object.
+type PlanKind string
+
+const (
+	PlanKindStage      PlanKind = "stage"
+	PlanKindResource   PlanKind = "resource"
+	PlanKindPlugin     PlanKind = "plugin"
+	PlanKindPermission PlanKind = "permission"
+)
+
+var planKindOrder = map[PlanKind]int{
+	PlanKindStage: 0, PlanKindResource: 1, PlanKindPlugin: 2, PlanKindPermission: 3,
+}
+
+var planActionSymbols = map[PlanAction]string{
+	PlanActionAdd: "+", PlanActionRemove: "-", PlanActionChange: "~", PlanActionGrant: "*", PlanActionUnmanaged: "?",
+}
+
+// FieldChange is a different field of a changed object.
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Local  interface{} `json:"local"`
+	Remote interface{} `json:"remote"`
+}
+
+// PlanChange is a change of an object.
+type PlanChange struct {
+	Kind   PlanKind      `json:"kind"`
+	Name   string        `json:"name"`
+	Action PlanAction    `json:"action"`
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// Plan is the difference between the local definition and what is in the gateway.
+type Plan struct {
+	APIName string `json:"api_name"`
+	// LatestVersion is the latest resource version in the gateway.
+	LatestVersion string `json:"latest_version"`
+	// Version is the resource version to release in the definition.
+	Version string        `json:"version"`
+	Changes []*PlanChange `json:"changes"`
+}
+
+// HasChanges returns true if the sync will change the gateway, which can be used as a CI gate.
+// The grants and the unmanaged objects are not counted.
+func (p *Plan) HasChanges() bool {
+	for _, change := range p.Changes {
+		if change.Action != PlanActionGrant && change.Action != PlanActionUnmanaged {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Count returns the number of changes of the action.
+func (p *Plan) Count(action PlanAction) int {
+	count := 0
+	for _, change := range p.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+// WriteJSON writes the plan as indented json.
+func (p *Plan) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(p)
+}
+
+// WriteText writes the plan as a human-readable diff.
+func (p *Plan) WriteText(writer io.Writer) error {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Plan for gateway %s (released: %s, local: %s)\n",
+		p.APIName, planValueOrNone(p.LatestVersion), planValueOrNone(p.Version))
+
+	for _, change := range p.Changes {
+		fmt.Fprintf(&builder, "  %s %s %s\n", planActionSymbols[change.Action], change.Kind, change.Name)
+		for _, field := range change.Fields {
+			fmt.Fprintf(&builder, "      %s: %s => %s\n",
+				field.Field, planFormatValue(field.Remote), planFormatValue(field.Local))
+		}
+	}
+
+	fmt.Fprintf(&builder, "%d to add, %d to change, %d to remove, %d to grant, %d unmanaged.\n",
+		p.Count(PlanActionAdd), p.Count(PlanActionChange), p.Count(PlanActionRemove), p.Count(PlanActionGrant),
+		p.Count(PlanActionUnmanaged))
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// String renders the plan as a human-readable diff.
+func (p *Plan) String() string {
+	var builder strings.Builder
+	_ = p.WriteText(&builder)
+	return builder.String()
+}
+
+func (p *Plan) add(kind PlanKind, name string, action PlanAction, fields ...FieldChange) {
+	p.Changes = append(p.Changes, &PlanChange{Kind: kind, Name: name, Action: action, Fields: fields})
+}
+
+func (p *Plan) sort() {
+	sort.SliceStable(p.Changes, func(i, j int) bool {
+		a, b := p.Changes[i], p.Changes[j]
+		if a.Kind != b.Kind {
+			return planKindOrder[a.Kind] < planKindOrder[b.Kind]
+		}
+		return a.Name < b.Name
+	})
+}
+
+func planValueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+
+	return value
+}
+
+func planFormatValue(value interface{}) string {
+	if value == nil {
+		return "<none>"
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(content)
+}
+
+// Plan compares the local definition and resources (the content of resources.yaml) with the stages and
+// the released resources in the gateway, nothing will be changed in the gateway.
+func (m *Manager) Plan(resources []byte) (*Plan, error) {
+	return m.PlanContext(context.Background(), resources)
+}
+
+// PlanContext is Plan with a context to cancel the requests.
+func (m *Manager) PlanContext(ctx context.Context, resources []byte) (*Plan, error) {
+	if m.definition == nil {
+		return nil, errors.Wrap(ErrNotFound, "definition is not loaded")
+	}
+
+	spec, err := parsePlanDefinition(m.definition)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse definition")
+	}
+
+	localResources, err := parsePlanResources(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{APIName: m.apiName, Changes: make([]*PlanChange, 0)}
+	if spec.Release != nil {
+		plan.Version = spec.Release.Version
+	}
+
+	latest, err := m.GetLatestResourceVersionContext(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get latest resource version")
+	}
+	plan.LatestVersion = latest.Version
+
+	remoteStages, err := m.GetStagesContext(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get stages")
+	}
+	stageVersions, err := m.GetStagesWithResourceVersionContext(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get stages with resource version")
+	}
+
+	remoteStageMap := make(map[string]map[string]interface{}, len(remoteStages))
+	for _, stage := range remoteStages {
+		if stage.Name != "" {
+			remoteStageMap[stage.Name] = stage.Raw
+		}
+	}
+	for _, stage := range stageVersions {
+		if remote, ok := remoteStageMap[stage.Name]; ok {
+			remote["resource_version"] = stage.Version
+		}
+	}
+
+	stageNames := make(map[string]bool)
+	for _, stage := range spec.Stages {
+		stageNames[stage.Name] = true
+		remote, ok := remoteStageMap[stage.Name]
+		if !ok {
+			plan.add(PlanKindStage, stage.Name, PlanActionAdd)
+			planPlugins(plan, stage.Name, stage.PluginConfigs, []interface{}{})
+			continue
+		}
+
+		local := map[string]interface{}{
+			"description":    stage.Description,
+			"description_en": stage.DescriptionEn,
+			"vars":           stage.Vars,
+		}
+		if plan.Version != "" {
+			local["resource_version"] = plan.Version
+		}
+		if fields := planCompare(local, remote); len(fields) > 0 {
+			plan.add(PlanKindStage, stage.Name, PlanActionChange, fields...)
+		}
+		planPlugins(plan, stage.Name, stage.PluginConfigs, remote["plugin_configs"])
+	}
+
+	// the sync never deletes the stages, so neither the stages nor their plugins will be removed
+	for _, name := range planSortedKeys(remoteStageMap) {
+		if !stageNames[name] {
+			plan.add(PlanKindStage, name, PlanActionUnmanaged)
+		}
+	}
+
+	// the resources are released to all the stages, so any released stage is enough to compare
+	remoteResources := make(map[string]map[string]interface{})
+	for _, stage := range stageVersions {
+		if stage.Name == "" || stage.Version == "" {
+			continue
+		}
+
+		released, err := m.GetReleasedResourcesContext(ctx, stage.Name)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get released resources of stage %s", stage.Name)
+		}
+		for _, resource := range released {
+			if resource.Name != "" {
+				remoteResources[resource.Name] = resource.Raw
+			}
+		}
+		break
+	}
+
+	for _, name := range planSortedKeys(localResources) {
+		resource := localResources[name]
+		remote, ok := remoteResources[name]
+		if !ok {
+			plan.add(PlanKindResource, name, PlanActionAdd)
+			planPlugins(plan, name, resource.plugins, []interface{}{})
+			continue
+		}
+
+		if fields := planCompare(resource.fields, remote); len(fields) > 0 {
+			plan.add(PlanKindResource, name, PlanActionChange, fields...)
+		}
+		planPlugins(plan, name, resource.plugins, remote["plugin_configs"])
+	}
+
+	for _, name := range planSortedKeys(remoteResources) {
+		if _, ok := localResources[name]; !ok {
+			plan.add(PlanKindResource, name, PlanActionRemove)
+		}
+	}
+
+	for _, permission := range spec.GrantPermissions {
+		name := fmt.Sprintf("%s/%s", permission.BkAppCode, permission.GrantDimension)
+		var fields []FieldChange
+		if len(permission.ResourceNames) > 0 {
+			fields = append(fields, FieldChange{Field: "resource_names", Local: permission.ResourceNames})
+		}
+		plan.add(PlanKindPermission, name, PlanActionGrant, fields...)
+	}
+
+	plan.sort()
+	return plan, nil
+}
+
+// planDefinition is the part of the definition which the plan cares about. It is decoded loosely like syncing,
+// so the fields which are not modeled by DefinitionSpec, such as apigateway.doc_maintainers, are allowed.
+type planDefinition struct {
+	Release *struct {
+		Version string `yaml:"version"`
+	} `yaml:"release"`
+	Stages []struct {
+		Name          string            `yaml:"name"`
+		Description   string            `yaml:"description"`
+		DescriptionEn string            `yaml:"description_en"`
+		Vars          map[string]string `yaml:"vars"`
+		PluginConfigs []PluginSpec      `yaml:"plugin_configs"`
+	} `yaml:"stages"`
+	GrantPermissions []struct {
+		BkAppCode      string   `yaml:"bk_app_code"`
+		GrantDimension string   `yaml:"grant_dimension"`
+		ResourceNames  []string `yaml:"resource_names"`
+	} `yaml:"grant_permissions"`
+}
+
+func parsePlanDefinition(definition *Definition) (*planDefinition, error) {
+	content := definition.rendered
+	if content == nil {
+		var err error
+		content, err = yaml.Marshal(definition.definition)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal yaml")
+		}
+	}
+
+	var spec planDefinition
+	err := yaml.Unmarshal(content, &spec)
+	if err != nil {
+		return nil, errors.Wrap(ErrDefinitionInvalid, err.Error())
+	}
+
+	for i, stage := range spec.Stages {
+		if stage.Name == "" {
+			return nil, errors.Wrapf(ErrDefinitionInvalid, "%s[%d].name must be a non-empty string", stagesNamespace, i)
+		}
+	}
+
+	return &spec, nil
+}
+
+// planResource is a resource defined in the resources.yaml.
+type planResource struct {
+	fields  map[string]interface{}
+	plugins []PluginSpec
+}
+
+// planSwaggerOperation is the part of the swagger operation which the plan cares about.
+type planSwaggerOperation struct {
+	OperationID string `yaml:"operationId"`
+	Summary     string `yaml:"summary"`
+	Description string `yaml:"description"`
+	Resource    *struct {
+		IsPublic             bool         `yaml:"isPublic"`
+		MatchSubpath         bool         `yaml:"matchSubpath"`
+		EnableWebsocket      bool         `yaml:"enableWebsocket"`
+		AllowApplyPermission bool         `yaml:"allowApplyPermission"`
+		PluginConfigs        []PluginSpec `yaml:"pluginConfigs"`
+	} `yaml:"x-bk-apigateway-resource"`
+}
+
+var planSwaggerMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true,
+}
+
+func parsePlanResources(content []byte) (map[string]*planResource, error) {
+	var document struct {
+		Paths map[string]map[string]yaml.Node `yaml:"paths"`
+	}
+	err := yaml.Unmarshal(content, &document)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal resources")
+	}
+
+	resources := make(map[string]*planResource)
+	for path, item := range document.Paths {
+		for method, node := range item {
+			if !planSwaggerMethods[strings.ToLower(method)] {
+				continue
+			}
+
+			var operation planSwaggerOperation
+			err = node.Decode(&operation)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode resource %s %s", method, path)
+			}
+			if operation.OperationID == "" {
+				return nil, errors.Wrapf(ErrDefinitionInvalid, "operationId of %s %s is required", method, path)
+			}
+
+			description := operation.Description
+			if description == "" {
+				description = operation.Summary
+			}
+			resource := &planResource{fields: map[string]interface{}{
+				"method":      strings.ToUpper(method),
+				"path":        path,
+				"description": description,
+			}}
+			if operation.Resource != nil {
+				resource.fields["is_public"] = operation.Resource.IsPublic
+				resource.fields["match_subpath"] = operation.Resource.MatchSubpath
+				resource.fields["enable_websocket"] = operation.Resource.EnableWebsocket
+				resource.fields["allow_apply_permission"] = operation.Resource.AllowApplyPermission
+				resource.plugins = operation.Resource.PluginConfigs
+			}
+			resources[operation.OperationID] = resource
+		}
+	}
+
+	return resources, nil
+}
+
+// planCompare returns the fields which are different, only the fields returned by the gateway are compared.
+func planCompare(local, remote map[string]interface{}) []FieldChange {
+	var fields []FieldChange
+	for _, key := range planSortedKeys(local) {
+		remoteValue, ok := remote[key]
+		if !ok {
+			continue
+		}
+		if !planEqual(local[key], remoteValue) {
+			fields = append(fields, FieldChange{Field: key, Local: local[key], Remote: remoteValue})
+		}
+	}
+
+	return fields
+}
+
+// planEqual compares the values after normalizing them by json, so the typed and the decoded values are comparable.
+func planEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(planNormalize(a), planNormalize(b))
+}
+
+func planNormalize(value interface{}) interface{} {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var result interface{}
+	if json.Unmarshal(content, &result) != nil {
+		return value
+	}
+
+	// treat the empty values as missing
+	switch v := result.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+	case string:
+		if v == "" {
+			return nil
+		}
+	}
+
+	return result
+}
+
+// planPlugins compares the plugin configs bound to the owner, they are skipped if the remote value is unknown.
+func planPlugins(plan *Plan, owner string, local []PluginSpec, remote interface{}) {
+	if remote == nil {
+		return
+	}
+
+	remotePlugins := make(map[string]string)
+	items, _ := remote.([]interface{})
+	for _, item := range items {
+		value, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pluginType, _ := value["type"].(string)
+		pluginYAML, _ := value["yaml"].(string)
+		remotePlugins[pluginType] = pluginYAML
+	}
+
+	localTypes := make(map[string]bool, len(local))
+	for _, plugin := range local {
+		localTypes[plugin.Type] = true
+		name := fmt.Sprintf("%s/%s", owner, plugin.Type)
+		remoteYAML, ok := remotePlugins[plugin.Type]
+		switch {
+		case !ok:
+			plan.add(PlanKindPlugin, name, PlanActionAdd)
+		case !planYAMLEqual(plugin.YAML, remoteYAML):
+			plan.add(PlanKindPlugin, name, PlanActionChange,
+				FieldChange{Field: "yaml", Local: plugin.YAML, Remote: remoteYAML})
+		}
+	}
+
+	for _, pluginType := range planSortedKeys(remotePlugins) {
+		if !localTypes[pluginType] {
+			plan.add(PlanKindPlugin, fmt.Sprintf("%s/%s", owner, pluginType), PlanActionRemove)
+		}
+	}
+}
+
+// planYAMLEqual compares the plugin configs semantically, so the formats are ignored.
+func planYAMLEqual(a, b string) bool {
+	var valueA, valueB interface{}
+	if yaml.Unmarshal([]byte(a), &valueA) != nil || yaml.Unmarshal([]byte(b), &valueB) != nil {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+
+	return reflect.DeepEqual(valueA, valueB)
+}
+
+func planSortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,207 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package manager_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gock "gopkg.in/h2non/gock.v1"
+
+	apigateway "github.com/TencentBlueKing/bk-apigateway-sdks/apigateway"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	manager "github.com/TencentBlueKing/bk-apigateway-sdks/manager"
+)
+
+var _ = Describe("Plan", func() {
+	var (
+		config bkapi.ClientConfig
+		mgr    *manager.Manager
+	)
+
+	resources := []byte(`swagger: "2.0"
+paths:
+  /pets/{pet_id}:
+    parameters:
+      - name: pet_id
+        in: path
+    get:
+      operationId: get_pet
+      summary: get a pet
+      x-bk-apigateway-resource:
+        isPublic: true
+        pluginConfigs:
+          - type: bk-cors
+            yaml: |
+              allow_origins: "*"
+  /pets:
+    post:
+      operationId: create_pet
+      summary: create a pet
+`)
+
+	reply := func(path string, data interface{}) {
+		gock.New(config.Endpoint).
+			Get(path + "$").
+			Reply(200).
+			JSON(map[string]interface{}{"code": 0, "data": data})
+	}
+
+	BeforeEach(func() {
+		config = bkapi.ClientConfig{Endpoint: "http://example.com"}
+
+		// doc_maintainers is not modeled by the spec, it should not break the plan
+		definition, err := manager.NewDefinitionFromYaml([]byte(`spec_version: 2
+apigateway:
+  description: testing
+  doc_maintainers:
+    type: user
+release:
+  version: "1.1.0"
+stages:
+  - name: prod
+    description: production
+    vars:
+      api_sub_path: /v2
+    plugin_configs:
+      - type: bk-rate-limit
+        yaml: "rates: {}"
+  - name: test
+grant_permissions:
+  - bk_app_code: demo
+    grant_dimension: api
+`))
+		Expect(err).To(BeNil())
+
+		mgr, err = manager.NewManager(
+			"testing", config, definition,
+			func(configProvider define.ClientConfigProvider, opts ...define.BkApiClientOption) (*apigateway.Client, error) {
+				opts = append(opts, bkapi.OptTransport(gock.NewTransport()))
+				return apigateway.New(configProvider, opts...)
+			},
+		)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		gock.Off()
+	})
+
+	It("should diff the definition and resources with the gateway", func() {
+		reply("/api/v1/apis/testing/resource_versions/latest/", map[string]interface{}{"version": "1.0.0"})
+		reply("/api/v1/apis/testing/stages/with-resource-version/", []interface{}{
+			map[string]interface{}{"name": "prod", "resource_version": map[string]interface{}{"version": "1.0.0"}},
+			map[string]interface{}{"name": "dev", "resource_version": map[string]interface{}{"version": "1.0.0"}},
+		})
+		reply("/api/v1/apis/testing/stages/", []interface{}{
+			map[string]interface{}{
+				"name":        "prod",
+				"description": "production",
+				"vars":        map[string]interface{}{"api_sub_path": "/v1"},
+				"plugin_configs": []interface{}{
+					map[string]interface{}{"type": "bk-rate-limit", "yaml": "rates: {}\n"},
+				},
+			},
+			map[string]interface{}{"name": "dev"},
+		})
+		reply("/api/v1/apis/testing/released/stages/prod/resources/", []interface{}{
+			map[string]interface{}{
+				"name": "get_pet", "method": "GET", "path": "/pets/{pet_id}", "is_public": false,
+				"plugin_configs": []interface{}{},
+			},
+			map[string]interface{}{"name": "delete_pet", "method": "DELETE", "path": "/pets/{pet_id}"},
+		})
+
+		plan, err := mgr.Plan(resources)
+		Expect(err).To(BeNil())
+		Expect(plan.HasChanges()).To(BeTrue())
+		Expect(plan.LatestVersion).To(Equal("1.0.0"))
+		Expect(plan.Version).To(Equal("1.1.0"))
+
+		summary := make([]string, 0, len(plan.Changes))
+		for _, change := range plan.Changes {
+			summary = append(summary, string(change.Action)+" "+string(change.Kind)+" "+change.Name)
+		}
+		Expect(summary).To(Equal([]string{
+			"unmanaged stage dev",
+			"change stage prod",
+			"add stage test",
+			"add resource create_pet",
+			"remove resource delete_pet",
+			"change resource get_pet",
+			"add plugin get_pet/bk-cors",
+			"grant permission demo/api",
+		}))
+
+		prod := plan.Changes[1]
+		Expect(prod.Fields).To(ConsistOf(
+			manager.FieldChange{
+				Field: "resource_version", Local: "1.1.0", Remote: "1.0.0",
+			},
+			manager.FieldChange{
+				Field:  "vars",
+				Local:  map[string]string{"api_sub_path": "/v2"},
+				Remote: map[string]interface{}{"api_sub_path": "/v1"},
+			},
+		))
+
+		text := plan.String()
+		Expect(text).To(ContainSubstring("  ? stage dev\n"))
+		Expect(text).To(ContainSubstring(`      vars: {"api_sub_path":"/v1"} => {"api_sub_path":"/v2"}`))
+		Expect(text).To(ContainSubstring("3 to add, 2 to change, 1 to remove, 1 to grant, 1 unmanaged."))
+
+		var buffer bytes.Buffer
+		Expect(plan.WriteJSON(&buffer)).To(Succeed())
+		var decoded map[string]interface{}
+		Expect(json.Unmarshal(buffer.Bytes(), &decoded)).To(Succeed())
+		Expect(decoded["changes"]).To(HaveLen(8))
+	})
+
+	It("should report no changes when the gateway is up to date", func() {
+		reply("/api/v1/apis/testing/resource_versions/latest/", map[string]interface{}{"version": "1.1.0"})
+		reply("/api/v1/apis/testing/stages/with-resource-version/", []interface{}{
+			map[string]interface{}{"name": "prod", "resource_version": map[string]interface{}{"version": "1.1.0"}},
+			map[string]interface{}{"name": "test", "resource_version": map[string]interface{}{"version": "1.1.0"}},
+		})
+		reply("/api/v1/apis/testing/stages/", []interface{}{
+			map[string]interface{}{"name": "prod", "vars": map[string]interface{}{"api_sub_path": "/v2"}},
+			map[string]interface{}{"name": "test"},
+			// the stages only in the gateway are never deleted by the sync
+			map[string]interface{}{"name": "dev"},
+		})
+		reply("/api/v1/apis/testing/released/stages/prod/resources/", map[string]interface{}{
+			"results": []interface{}{
+				map[string]interface{}{"name": "get_pet", "method": "GET", "path": "/pets/{pet_id}"},
+				map[string]interface{}{"name": "create_pet", "method": "POST", "path": "/pets"},
+			},
+		})
+
+		plan, err := mgr.Plan(resources)
+		Expect(err).To(BeNil())
+		Expect(plan.HasChanges()).To(BeFalse())
+		Expect(plan.Changes).To(HaveLen(2))
+		Expect(plan.Count(manager.PlanActionUnmanaged)).To(Equal(1))
+	})
+
+	It("should return an error when the gateway fails", func() {
+		gock.New(config.Endpoint).
+			Get("/api/v1/apis/testing/resource_versions/latest/").
+			Reply(200).
+			JSON(map[string]interface{}{"code": 1, "message": "forbidden"})
+
+		_, err := mgr.Plan(resources)
+		Expect(err).To(MatchError(manager.ErrApigatewayRequest))
+	})
+})
@@ -0,0 +1,96 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package manager
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+)
+
+// GetApis fetch the gateways from apigw, the query can filter them, such as {"name": "my-gateway"}.
+func (m *Manager) GetApis(query map[string]string) ([]*APIInfo, error) {
+	return m.GetApisContext(context.Background(), query)
+}
+
+// GetApisContext is GetApis with a context to cancel the requests.
+func (m *Manager) GetApisContext(ctx context.Context, query map[string]string) ([]*APIInfo, error) {
+	items, err := m.requestList(ctx, m.client.GetApis().SetQueryParams(query))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResults(items, func(result *APIInfo, raw map[string]interface{}) {
+		result.Raw = raw
+	}), nil
+}
+
+// GetStages fetch the stages of the gateway from apigw.
+func (m *Manager) GetStages() ([]*Stage, error) {
+	return m.GetStagesContext(context.Background())
+}
+
+// GetStagesContext is GetStages with a context to cancel the requests.
+func (m *Manager) GetStagesContext(ctx context.Context) ([]*Stage, error) {
+	items, err := m.requestList(ctx, m.client.GetStages())
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResults(items, func(result *Stage, raw map[string]interface{}) {
+		result.Raw = raw
+	}), nil
+}
+
+// GetStagesWithResourceVersion fetch the stages and the resource versions released to them from apigw.
+func (m *Manager) GetStagesWithResourceVersion() ([]*StageResourceVersion, error) {
+	return m.GetStagesWithResourceVersionContext(context.Background())
+}
+
+// GetStagesWithResourceVersionContext is GetStagesWithResourceVersion with a context to cancel the requests.
+func (m *Manager) GetStagesWithResourceVersionContext(ctx context.Context) ([]*StageResourceVersion, error) {
+	items, err := m.requestList(ctx, m.client.GetStagesWithResourceVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResults(items, func(result *StageResourceVersion, raw map[string]interface{}) {
+		// the resource version may be an object or a version string
+		result.Version = resourceVersionOf(raw["resource_version"])
+		result.Raw = raw
+	}), nil
+}
+
+// GetReleasedResources fetch the resources released to the stage from apigw.
+func (m *Manager) GetReleasedResources(stageName string) ([]*ReleasedResource, error) {
+	return m.GetReleasedResourcesContext(context.Background(), stageName)
+}
+
+// GetReleasedResourcesContext is GetReleasedResources with a context to cancel the requests.
+func (m *Manager) GetReleasedResourcesContext(ctx context.Context, stageName string) ([]*ReleasedResource, error) {
+	if stageName == "" {
+		return nil, errors.Wrap(define.ErrMissingPathParam, "stage_name")
+	}
+
+	items, err := m.requestList(ctx, m.client.GetReleasedResources().SetPathParams(
+		map[string]string{"stage_name": stageName},
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResults(items, func(result *ReleasedResource, raw map[string]interface{}) {
+		result.Raw = raw
+	}), nil
+}
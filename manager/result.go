@@ -0,0 +1,168 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/json"
+)
+
+// Result is the result of the operations which have no typed fields.
+type Result struct {
+	// Raw is the data of the gateway response, for forward compatibility.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// SyncAPIResult is the result of syncing the basic info of the gateway.
+type SyncAPIResult struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Raw is the data of the gateway response, for forward compatibility.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// StageSyncResult is the result of syncing a stage, or the mcp servers of a stage.
+type StageSyncResult struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Raw is the data of the gateway response, for forward compatibility.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// ResourceSyncItem is a resource changed by syncing.
+type ResourceSyncItem struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// ResourceSyncResult is the result of syncing the resources.
+type ResourceSyncResult struct {
+	Added   []ResourceSyncItem `json:"added"`
+	Updated []ResourceSyncItem `json:"updated"`
+	Deleted []ResourceSyncItem `json:"deleted"`
+	// Raw is the data of the gateway response, for forward compatibility.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// ResourceVersion is a resource version of the gateway.
+type ResourceVersion struct {
+	ID      int64  `json:"id"`
+	Version string `json:"version"`
+	Title   string `json:"title,omitempty"`
+	Comment string `json:"comment,omitempty"`
+	// Raw is the data of the gateway response, for forward compatibility.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// ReleaseResult is the result of releasing a resource version.
+type ReleaseResult struct {
+	Version    string   `json:"version"`
+	StageNames []string `json:"stage_names"`
+	// Raw is the data of the gateway response, for forward compatibility.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// PublicKeyInfo is the public key of the gateway.
+type PublicKeyInfo struct {
+	Issuer    string `json:"issuer,omitempty"`
+	PublicKey string `json:"public_key"`
+	// Raw is the data of the gateway response, for forward compatibility.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// GrantResult is the result of granting the permission to an app.
+type GrantResult struct {
+	BkAppCode      string `json:"bk_app_code"`
+	GrantDimension string `json:"grant_dimension"`
+	// Raw is the data of the gateway response, for forward compatibility.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// decodeResult decodes the data of the gateway response into the typed result field by field.
+// It is lenient for forward compatibility: a field whose type is changed by the gateway is left as it is,
+// the value is still available in the raw data, so a succeeded operation is never reported as failed.
+func decodeResult(data map[string]interface{}, result interface{}) {
+	for key, value := range data {
+		content, err := json.Marshal(map[string]interface{}{key: value})
+		if err != nil {
+			continue
+		}
+
+		// the error is ignored, the mismatched field keeps its value
+		_ = json.Unmarshal(content, result)
+	}
+}
+
+// APIInfo is a gateway.
+type APIInfo struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Raw is the data of the gateway response, for forward compatibility.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Stage is a stage of the gateway.
+type Stage struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Raw is the data of the gateway response, for forward compatibility.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// StageResourceVersion is a stage and the resource version released to it.
+type StageResourceVersion struct {
+	Name string `json:"name"`
+	// Version is the released resource version, empty if nothing has been released.
+	Version string `json:"-"`
+	// Raw is the data of the gateway response, for forward compatibility.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// resourceVersionOf returns the version from a resource version object or a version string.
+func resourceVersionOf(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		version, _ := v["version"].(string)
+		return version
+	default:
+		return ""
+	}
+}
+
+// ReleasedResource is a resource released to a stage.
+type ReleasedResource struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Raw is the data of the gateway response, for forward compatibility.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// decodeResults decodes each item of the gateway response, setRaw keeps the item in the result.
+func decodeResults[T any](
+	items []map[string]interface{},
+	setRaw func(result *T, raw map[string]interface{}),
+) []*T {
+	results := make([]*T, 0, len(items))
+	for _, item := range items {
+		result := new(T)
+		decodeResult(item, result)
+		setRaw(result, item)
+		results = append(results, result)
+	}
+
+	return results
+}
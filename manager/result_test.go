@@ -0,0 +1,200 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package manager_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gock "gopkg.in/h2non/gock.v1"
+
+	apigateway "github.com/TencentBlueKing/bk-apigateway-sdks/apigateway"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/bkapi"
+	"github.com/TencentBlueKing/bk-apigateway-sdks/core/define"
+	manager "github.com/TencentBlueKing/bk-apigateway-sdks/manager"
+)
+
+var _ = Describe("Result", func() {
+	var (
+		ctx    = context.Background()
+		config bkapi.ClientConfig
+		mgr    *manager.Manager
+	)
+
+	reply := func(method, path string, data interface{}) {
+		request := gock.New(config.Endpoint)
+		if method == "GET" {
+			request = request.Get(path + "$")
+		} else {
+			request = request.Post(path + "$")
+		}
+		request.Reply(200).JSON(map[string]interface{}{"code": 0, "data": data})
+	}
+
+	BeforeEach(func() {
+		config = bkapi.ClientConfig{Endpoint: "http://example.com"}
+
+		definition, err := manager.NewDefinitionFromYaml([]byte(`spec_version: 2
+apigateway:
+  description: testing
+stages:
+  - name: prod
+  - name: test
+grant_permissions:
+  - bk_app_code: demo
+    grant_dimension: api
+`))
+		Expect(err).To(BeNil())
+
+		mgr, err = manager.NewManager(
+			"testing", config, definition,
+			func(configProvider define.ClientConfigProvider, opts ...define.BkApiClientOption) (*apigateway.Client, error) {
+				opts = append(opts, bkapi.OptTransport(gock.NewTransport()))
+				return apigateway.New(configProvider, opts...)
+			},
+		)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		gock.Off()
+	})
+
+	It("should decode the sync api result", func() {
+		reply("POST", "/api/v1/apis/testing/sync/", map[string]interface{}{"id": 1, "name": "testing", "extra": true})
+
+		result, err := mgr.SyncBasicInfoContext(ctx)
+		Expect(err).To(BeNil())
+		Expect(result.ID).To(Equal(int64(1)))
+		Expect(result.Name).To(Equal("testing"))
+		Expect(result.Raw).To(HaveKeyWithValue("extra", true))
+	})
+
+	It("should not fail when the type of a field is changed", func() {
+		reply("POST", "/api/v1/apis/testing/resource_versions/", map[string]interface{}{"id": "10", "version": "1.0.0"})
+
+		version, err := mgr.CreateResourceVersionContext(ctx, "1.0.0", "first")
+		Expect(err).To(BeNil())
+		Expect(version.ID).To(BeZero())
+		Expect(version.Version).To(Equal("1.0.0"))
+		Expect(version.Raw).To(HaveKeyWithValue("id", "10"))
+	})
+
+	It("should decode the stage results in order", func() {
+		reply("POST", "/api/v1/apis/testing/stages/sync/", map[string]interface{}{"id": 1})
+		reply("POST", "/api/v1/apis/testing/stages/sync/", map[string]interface{}{"id": 2, "name": "test"})
+
+		results, err := mgr.SyncStagesConfigContext(ctx)
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].ID).To(Equal(int64(1)))
+		Expect(results[0].Name).To(Equal("prod"))
+		Expect(results[1].Name).To(Equal("test"))
+	})
+
+	It("should decode the resource sync result", func() {
+		reply("POST", "/api/v1/apis/testing/resources/sync/", map[string]interface{}{
+			"added":   []interface{}{map[string]interface{}{"id": 1}},
+			"updated": []interface{}{map[string]interface{}{"id": 2}, map[string]interface{}{"id": 3}},
+			"deleted": []interface{}{},
+		})
+
+		result, err := mgr.SyncResourcesConfigContext(ctx, map[string]interface{}{"content": ""})
+		Expect(err).To(BeNil())
+		Expect(result.Added).To(Equal([]manager.ResourceSyncItem{{ID: 1}}))
+		Expect(result.Updated).To(HaveLen(2))
+		Expect(result.Deleted).To(BeEmpty())
+	})
+
+	It("should return an empty version when no version is created", func() {
+		reply("GET", "/api/v1/apis/testing/resource_versions/latest/", map[string]interface{}{})
+
+		version, err := mgr.GetLatestResourceVersionContext(ctx)
+		Expect(err).To(BeNil())
+		Expect(version.Version).To(BeEmpty())
+	})
+
+	It("should decode the resource version and the release result", func() {
+		reply("POST", "/api/v1/apis/testing/resource_versions/", map[string]interface{}{"id": 10, "version": "1.0.0"})
+		reply("POST", "/api/v1/apis/testing/resource_versions/release/", map[string]interface{}{})
+
+		version, err := mgr.CreateResourceVersionContext(ctx, "1.0.0", "first")
+		Expect(err).To(BeNil())
+		Expect(version.ID).To(Equal(int64(10)))
+		Expect(version.Comment).To(Equal("first"))
+
+		release, err := mgr.ReleaseContext(ctx, "1.0.0")
+		Expect(err).To(BeNil())
+		Expect(release.Version).To(Equal("1.0.0"))
+		Expect(release.StageNames).To(Equal([]string{"prod", "test"}))
+	})
+
+	It("should decode the public key and the grant results", func() {
+		reply("GET", "/api/v1/apis/testing/public_key/", map[string]interface{}{"issuer": "apigw", "public_key": "key"})
+		reply("POST", "/api/v1/apis/testing/permissions/grant/", map[string]interface{}{})
+
+		info, err := mgr.GetPublicKeyContext(ctx)
+		Expect(err).To(BeNil())
+		Expect(info.Issuer).To(Equal("apigw"))
+		Expect(info.PublicKey).To(Equal("key"))
+
+		grants, err := mgr.GrantPermissionsContext(ctx)
+		Expect(err).To(BeNil())
+		Expect(grants).To(HaveLen(1))
+		Expect(grants[0].BkAppCode).To(Equal("demo"))
+		Expect(grants[0].GrantDimension).To(Equal("api"))
+	})
+
+	It("should keep the map results of the existing methods", func() {
+		reply("POST", "/api/v1/apis/testing/sync/", map[string]interface{}{"id": 1})
+		reply("POST", "/api/v1/apis/testing/stages/sync/", map[string]interface{}{"id": 1})
+		reply("POST", "/api/v1/apis/testing/stages/sync/", map[string]interface{}{"id": 2})
+		reply("POST", "/api/v1/apis/testing/permissions/grant/", map[string]interface{}{"ok": true})
+
+		info, err := mgr.SyncBasicInfo()
+		Expect(err).To(BeNil())
+		Expect(info).To(HaveKeyWithValue("id", BeNumerically("==", 1)))
+
+		stages, err := mgr.SyncStagesConfig()
+		Expect(err).To(BeNil())
+		Expect(stages).To(HaveKey("prod"))
+		Expect(stages).To(HaveKey("test"))
+
+		grants, err := mgr.GrantPermissions()
+		Expect(err).To(BeNil())
+		Expect(grants).To(HaveKeyWithValue("result_0", map[string]interface{}{"ok": true}))
+	})
+
+	It("should return the error of the gateway", func() {
+		gock.New(config.Endpoint).
+			Post("/api/v1/apis/testing/sync/").
+			Reply(200).
+			JSON(map[string]interface{}{"code": 1, "message": "failed"})
+
+		result, err := mgr.SyncBasicInfoContext(ctx)
+		Expect(err).To(MatchError(manager.ErrApigatewayRequest))
+		Expect(result).To(BeNil())
+	})
+
+	It("should cancel the requests by the context", func() {
+		reply("POST", "/api/v1/apis/testing/sync/", map[string]interface{}{"id": 1})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		result, err := mgr.SyncBasicInfoContext(ctx)
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		Expect(result).To(BeNil())
+	})
+})
@@ -0,0 +1,446 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// DefinitionSpecVersion is the supported spec_version of the definition.
+const DefinitionSpecVersion = 2
+
+// DefinitionSpec is the typed definition of spec_version 2.
+// The schema tag declares the constraints used by the validation and the JSON Schema:
+// required, enum=a|b, min=n and desc=text, separated by semicolons.
+type DefinitionSpec struct {
+	SpecVersion      int                   `yaml:"spec_version" json:"spec_version" schema:"required;enum=2;desc=version of the definition format"`
+	Release          *ReleaseSpec          `yaml:"release,omitempty" json:"release,omitempty" schema:"desc=the resource version to create and release"`
+	APIGateway       *APIGatewaySpec       `yaml:"apigateway,omitempty" json:"apigateway,omitempty" schema:"desc=basic info of the gateway"`
+	Stages           []StageSpec           `yaml:"stages,omitempty" json:"stages,omitempty" schema:"desc=stages of the gateway"`
+	GrantPermissions []GrantPermissionSpec `yaml:"grant_permissions,omitempty" json:"grant_permissions,omitempty" schema:"desc=permissions granted to the apps"`
+	RelatedApps      []string              `yaml:"related_apps,omitempty" json:"related_apps,omitempty" schema:"desc=apps allowed to manage the gateway"`
+	ResourceDocs     *ResourceDocsSpec     `yaml:"resource_docs,omitempty" json:"resource_docs,omitempty" schema:"desc=documents of the resources"`
+}
+
+// ReleaseSpec is the resource version to create and release.
+type ReleaseSpec struct {
+	Version string `yaml:"version" json:"version" schema:"required;desc=semantic version of the resources, such as 1.0.0"`
+	Title   string `yaml:"title,omitempty" json:"title,omitempty"`
+	Comment string `yaml:"comment,omitempty" json:"comment,omitempty"`
+}
+
+// APIGatewaySpec is the basic info of the gateway.
+type APIGatewaySpec struct {
+	Description   string   `yaml:"description,omitempty" json:"description,omitempty"`
+	DescriptionEn string   `yaml:"description_en,omitempty" json:"description_en,omitempty"`
+	IsPublic      bool     `yaml:"is_public" json:"is_public"`
+	APIType       int      `yaml:"api_type,omitempty" json:"api_type,omitempty" schema:"enum=1|10;desc=1 is official, 10 is normal"`
+	Maintainers   []string `yaml:"maintainers,omitempty" json:"maintainers,omitempty" schema:"desc=usernames of the maintainers"`
+}
+
+// StageSpec is a stage of the gateway.
+type StageSpec struct {
+	Name          string            `yaml:"name" json:"name" schema:"required"`
+	Description   string            `yaml:"description,omitempty" json:"description,omitempty"`
+	DescriptionEn string            `yaml:"description_en,omitempty" json:"description_en,omitempty"`
+	Vars          map[string]string `yaml:"vars,omitempty" json:"vars,omitempty" schema:"desc=variables of the stage"`
+	Backends      []BackendSpec     `yaml:"backends,omitempty" json:"backends,omitempty"`
+	PluginConfigs []PluginSpec      `yaml:"plugin_configs,omitempty" json:"plugin_configs,omitempty"`
+	McpServers    []McpServerSpec   `yaml:"mcp_servers,omitempty" json:"mcp_servers,omitempty"`
+}
+
+// BackendSpec is a backend service of a stage.
+type BackendSpec struct {
+	Name   string            `yaml:"name" json:"name" schema:"required"`
+	Config BackendConfigSpec `yaml:"config" json:"config" schema:"required"`
+}
+
+// BackendConfigSpec is the config of a backend service.
+type BackendConfigSpec struct {
+	Timeout     int               `yaml:"timeout,omitempty" json:"timeout,omitempty" schema:"min=0;desc=timeout in seconds"`
+	LoadBalance string            `yaml:"loadbalance,omitempty" json:"loadbalance,omitempty" schema:"enum=roundrobin|weighted-roundrobin"`
+	Hosts       []BackendHostSpec `yaml:"hosts" json:"hosts" schema:"required"`
+}
+
+// BackendHostSpec is a host of a backend service.
+type BackendHostSpec struct {
+	Host   string `yaml:"host" json:"host" schema:"required;desc=address with the scheme, such as http://127.0.0.1:8000"`
+	Weight int    `yaml:"weight,omitempty" json:"weight,omitempty" schema:"min=0"`
+}
+
+// PluginSpec is a plugin config of a stage.
+type PluginSpec struct {
+	Type string `yaml:"type" json:"type" schema:"required;desc=plugin type, such as bk-cors"`
+	YAML string `yaml:"yaml" json:"yaml" schema:"required;desc=plugin config in yaml"`
+}
+
+// McpServerSpec is a mcp server of a stage.
+type McpServerSpec struct {
+	Name           string   `yaml:"name" json:"name" schema:"required"`
+	Description    string   `yaml:"description,omitempty" json:"description,omitempty"`
+	IsPublic       bool     `yaml:"is_public" json:"is_public"`
+	Status         int      `yaml:"status" json:"status" schema:"enum=0|1;desc=1 is enabled, 0 is disabled"`
+	Labels         []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Tools          []string `yaml:"tools,omitempty" json:"tools,omitempty" schema:"desc=resource names exposed as tools"`
+	TargetAppCodes []string `yaml:"target_app_codes,omitempty" json:"target_app_codes,omitempty"`
+}
+
+// GrantPermissionSpec is a permission granted to an app.
+type GrantPermissionSpec struct {
+	BkAppCode      string   `yaml:"bk_app_code" json:"bk_app_code" schema:"required"`
+	GrantDimension string   `yaml:"grant_dimension" json:"grant_dimension" schema:"required;enum=api|resource"`
+	ResourceNames  []string `yaml:"resource_names,omitempty" json:"resource_names,omitempty" schema:"desc=required when the grant dimension is resource"`
+}
+
+// ResourceDocsSpec is the documents of the resources.
+type ResourceDocsSpec struct {
+	BaseDir           string            `yaml:"basedir,omitempty" json:"basedir,omitempty" schema:"desc=directory of the markdown documents"`
+	ArchiveFile       string            `yaml:"archivefile,omitempty" json:"archivefile,omitempty" schema:"desc=archive of the documents"`
+	Swagger           string            `yaml:"swagger,omitempty" json:"swagger,omitempty" schema:"desc=swagger file of the documents"`
+	Language          string            `yaml:"language,omitempty" json:"language,omitempty" schema:"enum=zh|en;desc=language of the documents, defaults to zh"`
+	CustomFilenameMap map[string]string `yaml:"custom_filename_map,omitempty" json:"custom_filename_map,omitempty"`
+}
+
+// ValidationError is a problem of the definition at the yaml path.
+type ValidationError struct {
+	// Path is the yaml path of the field, such as stages[0].backends[0].name.
+	Path string
+	// Line is the line number in the definition, 0 if unknown.
+	Line    int
+	Message string
+}
+
+// Error renders the error message.
+func (e *ValidationError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s: %s (line %d)", e.Path, e.Message, e.Line)
+	}
+
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors collects all the problems of the definition.
+type ValidationErrors []*ValidationError
+
+// Error renders all the problems.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return fmt.Sprintf("%s: %s", ErrDefinitionInvalid, strings.Join(messages, "; "))
+}
+
+// Cause always return ErrDefinitionInvalid
+func (e ValidationErrors) Cause() error {
+	return ErrDefinitionInvalid
+}
+
+// Unwrap always return ErrDefinitionInvalid
+func (e ValidationErrors) Unwrap() error {
+	return ErrDefinitionInvalid
+}
+
+func (e *ValidationErrors) add(path string, line int, format string, args ...interface{}) {
+	*e = append(*e, &ValidationError{Path: path, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// ParseDefinitionSpec decodes the definition strictly, unknown fields are reported as errors,
+// and validates it, all the problems are returned as ValidationErrors.
+func ParseDefinitionSpec(content []byte) (*DefinitionSpec, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(content, &document)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal yaml")
+	}
+
+	var errs ValidationErrors
+	spec := &DefinitionSpec{}
+	if len(document.Content) == 0 {
+		errs.add("spec_version", 0, "is required")
+		return spec, errs
+	}
+
+	present := make(map[string]bool)
+	checkSpecFields(document.Content[0], reflect.TypeOf(spec), "", present, &errs)
+
+	err = document.Content[0].Decode(spec)
+	if err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, errors.Wrap(err, "failed to decode definition")
+		}
+		for _, message := range typeErr.Errors {
+			errs.add("", 0, "%s", message)
+		}
+	}
+
+	errs = append(errs, spec.validate(present)...)
+	if len(errs) > 0 {
+		return spec, errs
+	}
+
+	return spec, nil
+}
+
+// Validate checks the definition and returns all the problems as ValidationErrors.
+// The optional fields of zero values are treated as absent, as they are omitted when the spec is encoded.
+func (s *DefinitionSpec) Validate() error {
+	errs := s.validate(nil)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validate checks the spec, present is the set of the paths in the definition,
+// the enum of a zero value is checked only when it is present, like the JSON Schema does.
+func (s *DefinitionSpec) validate(present map[string]bool) ValidationErrors {
+	var errs ValidationErrors
+	validateSpecValue(reflect.ValueOf(s), "", present, &errs)
+
+	stageNames := make(map[string]bool, len(s.Stages))
+	for i, stage := range s.Stages {
+		path := fmt.Sprintf("stages[%d]", i)
+		if stage.Name != "" {
+			if stageNames[stage.Name] {
+				errs.add(path+".name", 0, "duplicated stage %s", stage.Name)
+			}
+			stageNames[stage.Name] = true
+		}
+
+		backendNames := make(map[string]bool, len(stage.Backends))
+		for j, backend := range stage.Backends {
+			backendPath := fmt.Sprintf("%s.backends[%d]", path, j)
+			if backend.Name != "" {
+				if backendNames[backend.Name] {
+					errs.add(backendPath+".name", 0, "duplicated backend %s", backend.Name)
+				}
+				backendNames[backend.Name] = true
+			}
+
+			for k, host := range backend.Config.Hosts {
+				if host.Host != "" && !strings.HasPrefix(host.Host, "http://") &&
+					!strings.HasPrefix(host.Host, "https://") {
+					errs.add(fmt.Sprintf("%s.config.hosts[%d].host", backendPath, k), 0,
+						"should start with http:// or https://")
+				}
+			}
+		}
+
+		for j, plugin := range stage.PluginConfigs {
+			var config interface{}
+			if err := yaml.Unmarshal([]byte(plugin.YAML), &config); err != nil {
+				errs.add(fmt.Sprintf("%s.plugin_configs[%d].yaml", path, j), 0, "invalid yaml: %v", err)
+			}
+		}
+
+		serverNames := make(map[string]bool, len(stage.McpServers))
+		for j, server := range stage.McpServers {
+			if server.Name != "" {
+				if serverNames[server.Name] {
+					errs.add(fmt.Sprintf("%s.mcp_servers[%d].name", path, j), 0,
+						"duplicated mcp server %s", server.Name)
+				}
+				serverNames[server.Name] = true
+			}
+		}
+	}
+
+	for i, permission := range s.GrantPermissions {
+		if permission.GrantDimension == "resource" && len(permission.ResourceNames) == 0 {
+			errs.add(fmt.Sprintf("grant_permissions[%d].resource_names", i), 0,
+				"is required when grant_dimension is resource")
+		}
+	}
+
+	return errs
+}
+
+// Spec decodes the definition to the typed spec strictly and validates it.
+func (d *Definition) Spec() (*DefinitionSpec, error) {
+	content := d.rendered
+	if content == nil {
+		var err error
+		content, err = yaml.Marshal(d.definition)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal yaml")
+		}
+	}
+
+	return ParseDefinitionSpec(content)
+}
+
+// specTag is the parsed schema tag of a field.
+type specTag struct {
+	required    bool
+	enum        []string
+	min         *int
+	description string
+}
+
+func parseSpecTag(field reflect.StructField) specTag {
+	var tag specTag
+	for _, item := range strings.Split(field.Tag.Get("schema"), ";") {
+		key, value, _ := strings.Cut(item, "=")
+		switch key {
+		case "required":
+			tag.required = true
+		case "enum":
+			tag.enum = strings.Split(value, "|")
+		case "min":
+			if n, err := strconv.Atoi(value); err == nil {
+				tag.min = &n
+			}
+		case "desc":
+			tag.description = value
+		}
+	}
+
+	return tag
+}
+
+// specFieldName returns the yaml name of the field, empty if it is ignored.
+func specFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" || !field.IsExported() {
+		return ""
+	}
+
+	return name
+}
+
+func joinSpecPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// checkSpecFields reports the keys of the node which are not defined by the type,
+// and collects the paths of the defined ones into present.
+func checkSpecFields(node *yaml.Node, typ reflect.Type, path string, present map[string]bool, errs *ValidationErrors) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+
+		fields := make(map[string]reflect.StructField, typ.NumField())
+		for i := 0; i < typ.NumField(); i++ {
+			if name := specFieldName(typ.Field(i)); name != "" {
+				fields[name] = typ.Field(i)
+			}
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			field, ok := fields[key.Value]
+			if !ok {
+				errs.add(joinSpecPath(path, key.Value), key.Line, "unknown field")
+				continue
+			}
+			present[joinSpecPath(path, key.Value)] = true
+			checkSpecFields(node.Content[i+1], field.Type, joinSpecPath(path, key.Value), present, errs)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, item := range node.Content {
+			checkSpecFields(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, i), present, errs)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			checkSpecFields(node.Content[i+1], typ.Elem(), joinSpecPath(path, node.Content[i].Value), present, errs)
+		}
+	}
+}
+
+// validateSpecValue checks the constraints declared by the schema tags.
+func validateSpecValue(value reflect.Value, path string, present map[string]bool, errs *ValidationErrors) {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		typ := value.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			name := specFieldName(typ.Field(i))
+			if name == "" {
+				continue
+			}
+
+			fieldPath := joinSpecPath(path, name)
+			fieldValue := value.Field(i)
+			tag := parseSpecTag(typ.Field(i))
+			if fieldValue.IsZero() || (fieldValue.Kind() == reflect.Slice && fieldValue.Len() == 0) {
+				if tag.required {
+					errs.add(fieldPath, 0, "is required")
+				} else if present[fieldPath] {
+					validateSpecEnum(fieldValue, fieldPath, tag.enum, errs)
+				}
+				continue
+			}
+
+			validateSpecEnum(fieldValue, fieldPath, tag.enum, errs)
+
+			if tag.min != nil && fieldValue.CanInt() && fieldValue.Int() < int64(*tag.min) {
+				errs.add(fieldPath, 0, "should be greater than or equal to %d", *tag.min)
+			}
+
+			validateSpecValue(fieldValue, fieldPath, present, errs)
+		}
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			validateSpecValue(value.Index(i), fmt.Sprintf("%s[%d]", path, i), present, errs)
+		}
+	}
+}
+
+func validateSpecEnum(value reflect.Value, path string, enum []string, errs *ValidationErrors) {
+	if len(enum) == 0 {
+		return
+	}
+
+	actual := fmt.Sprintf("%v", value.Interface())
+	for _, expected := range enum {
+		if actual == expected {
+			return
+		}
+	}
+
+	errs.add(path, 0, "should be one of %s, but got %s", strings.Join(enum, ", "), actual)
+}
@@ -0,0 +1,125 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package manager
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// DefinitionJSONSchemaID is the $schema of the exported JSON Schema.
+const DefinitionJSONSchemaID = "http://json-schema.org/draft-07/schema#"
+
+// DefinitionJSONSchema returns the JSON Schema of the definition, which can be used by the editors
+// to complete and check the definition.yaml.
+func DefinitionJSONSchema() map[string]interface{} {
+	schema := specTypeSchema(reflect.TypeOf(DefinitionSpec{}))
+	schema["$schema"] = DefinitionJSONSchemaID
+	schema["title"] = "bk-apigateway definition"
+
+	return schema
+}
+
+// WriteDefinitionJSONSchema writes the indented JSON Schema of the definition.
+func WriteDefinitionJSONSchema(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(DefinitionJSONSchema())
+}
+
+func specTypeSchema(typ reflect.Type) map[string]interface{} {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]interface{}, typ.NumField())
+		required := make([]string, 0)
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			name := specFieldName(field)
+			if name == "" {
+				continue
+			}
+
+			tag := parseSpecTag(field)
+			property := specTypeSchema(field.Type)
+			if tag.description != "" {
+				property["description"] = tag.description
+			}
+			if len(tag.enum) > 0 {
+				property["enum"] = specEnumValues(field.Type, tag.enum)
+			}
+			if tag.min != nil {
+				property["minimum"] = *tag.min
+			}
+			if tag.required {
+				required = append(required, name)
+				if field.Type.Kind() == reflect.Slice {
+					property["minItems"] = 1
+				}
+			}
+			properties[name] = property
+		}
+
+		schema := map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+
+		return schema
+	case reflect.Slice:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": specTypeSchema(typ.Elem()),
+		}
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": specTypeSchema(typ.Elem()),
+		}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// specEnumValues converts the enum values to the type of the field.
+func specEnumValues(typ reflect.Type, enum []string) []interface{} {
+	values := make([]interface{}, 0, len(enum))
+	for _, value := range enum {
+		if typ.Kind() == reflect.Int {
+			if n, err := strconv.Atoi(value); err == nil {
+				values = append(values, n)
+				continue
+			}
+		}
+		values = append(values, value)
+	}
+
+	return values
+}
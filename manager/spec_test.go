@@ -0,0 +1,310 @@
+/**
+ * TencentBlueKing is pleased to support the open source community by
+ * making 蓝鲸智云-蓝鲸 PaaS 平台(BlueKing-PaaS) available.
+ * Copyright (C) 2025 Tencent. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package manager_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	yaml "gopkg.in/yaml.v3"
+
+	manager "github.com/TencentBlueKing/bk-apigateway-sdks/manager"
+)
+
+var _ = Describe("DefinitionSpec", func() {
+	validDefinition := `spec_version: 2
+release:
+  version: "1.0.0"
+  comment: "first release"
+apigateway:
+  description: "testing"
+  is_public: true
+  api_type: 10
+  maintainers: ["admin"]
+stages:
+  - name: prod
+    vars:
+      api_sub_path: /prod
+      port: 8000
+    backends:
+      - name: default
+        config:
+          timeout: 60
+          loadbalance: roundrobin
+          hosts:
+            - host: http://127.0.0.1:8000
+              weight: 100
+    plugin_configs:
+      - type: bk-cors
+        yaml: |
+          allow_origins: "*"
+    mcp_servers:
+      - name: tools
+        is_public: false
+        status: 1
+        tools: ["hello"]
+grant_permissions:
+  - bk_app_code: demo
+    grant_dimension: resource
+    resource_names: ["hello"]
+related_apps:
+resource_docs:
+  basedir: docs/
+`
+
+	It("should decode a valid definition", func() {
+		spec, err := manager.ParseDefinitionSpec([]byte(validDefinition))
+		Expect(err).To(BeNil())
+		Expect(spec.SpecVersion).To(Equal(2))
+		Expect(spec.Release.Version).To(Equal("1.0.0"))
+		Expect(spec.Stages).To(HaveLen(1))
+		Expect(spec.Stages[0].Vars).To(HaveKeyWithValue("port", "8000"))
+		Expect(spec.Stages[0].Backends[0].Config.Hosts[0].Host).To(Equal("http://127.0.0.1:8000"))
+		Expect(spec.Stages[0].McpServers[0].Tools).To(ConsistOf("hello"))
+		Expect(spec.GrantPermissions[0].ResourceNames).To(ConsistOf("hello"))
+		Expect(spec.ResourceDocs.BaseDir).To(Equal("docs/"))
+	})
+
+	It("should decode the spec from a definition", func() {
+		definition, err := manager.NewDefinitionFromYaml([]byte(validDefinition))
+		Expect(err).To(BeNil())
+
+		spec, err := definition.Spec()
+		Expect(err).To(BeNil())
+		Expect(spec.Stages[0].Name).To(Equal("prod"))
+	})
+
+	It("should report unknown fields with yaml paths", func() {
+		_, err := manager.ParseDefinitionSpec([]byte(`spec_version: 2
+stages:
+  - name: prod
+    backend:
+      - name: default
+`))
+		Expect(errors.Is(err, manager.ErrDefinitionInvalid)).To(BeTrue())
+
+		var errs manager.ValidationErrors
+		Expect(errors.As(err, &errs)).To(BeTrue())
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Path).To(Equal("stages[0].backend"))
+		Expect(errs[0].Line).To(Equal(4))
+		Expect(errs[0].Message).To(Equal("unknown field"))
+	})
+
+	It("should return all the errors with yaml paths", func() {
+		_, err := manager.ParseDefinitionSpec([]byte(`spec_version: 1
+stages:
+  - name: prod
+    backends:
+      - name: default
+        config:
+          loadbalance: random
+          hosts:
+            - host: 127.0.0.1
+  - name: prod
+    plugin_configs:
+      - type: bk-cors
+        yaml: "a: [b"
+grant_permissions:
+  - bk_app_code: demo
+    grant_dimension: resource
+`))
+
+		var errs manager.ValidationErrors
+		Expect(errors.As(err, &errs)).To(BeTrue())
+
+		paths := make([]string, 0, len(errs))
+		for _, e := range errs {
+			paths = append(paths, e.Path)
+		}
+		Expect(paths).To(ConsistOf(
+			"spec_version",
+			"stages[0].backends[0].config.loadbalance",
+			"stages[0].backends[0].config.hosts[0].host",
+			"stages[1].name",
+			"stages[1].plugin_configs[0].yaml",
+			"grant_permissions[0].resource_names",
+		))
+	})
+
+	It("should report the missing required fields", func() {
+		spec := &manager.DefinitionSpec{
+			SpecVersion: 2,
+			Stages:      []manager.StageSpec{{Backends: []manager.BackendSpec{{Name: "default"}}}},
+		}
+
+		err := spec.Validate()
+		Expect(err).To(MatchError(ContainSubstring("stages[0].name: is required")))
+		Expect(err).To(MatchError(ContainSubstring("stages[0].backends[0].config: is required")))
+	})
+
+	It("should export the json schema", func() {
+		var buffer bytes.Buffer
+		Expect(manager.WriteDefinitionJSONSchema(&buffer)).To(Succeed())
+
+		var schema map[string]interface{}
+		Expect(json.Unmarshal(buffer.Bytes(), &schema)).To(Succeed())
+		Expect(schema["$schema"]).To(Equal(manager.DefinitionJSONSchemaID))
+		Expect(schema["required"]).To(ConsistOf("spec_version"))
+		Expect(schema["additionalProperties"]).To(BeFalse())
+
+		properties := schema["properties"].(map[string]interface{})
+		stages := properties["stages"].(map[string]interface{})
+		Expect(stages["type"]).To(Equal("array"))
+		stage := stages["items"].(map[string]interface{})
+		Expect(stage["required"]).To(ConsistOf("name"))
+
+		permissions := properties["grant_permissions"].(map[string]interface{})
+		permission := permissions["items"].(map[string]interface{})["properties"].(map[string]interface{})
+		Expect(permission["grant_dimension"].(map[string]interface{})["enum"]).To(ConsistOf("api", "resource"))
+	})
+
+	DescribeTable("should agree with the json schema", func(apigateway string, valid bool) {
+		content := []byte("spec_version: 2\napigateway:\n" + apigateway)
+
+		expectSchemaAgreement(content, valid)
+	},
+		Entry("absent api_type", "  is_public: true\n", true),
+		Entry("valid api_type", "  api_type: 10\n", true),
+		Entry("zero api_type", "  api_type: 0\n", false),
+		Entry("invalid api_type", "  api_type: 2\n", false),
+	)
+
+	DescribeTable("should check the enum of a present zero value only", func(config string, valid bool) {
+		content := []byte(`spec_version: 2
+stages:
+  - name: prod
+    backends:
+      - name: default
+        config:
+          hosts:
+            - host: http://127.0.0.1
+` + config)
+
+		expectSchemaAgreement(content, valid)
+	},
+		Entry("absent loadbalance", "", true),
+		Entry("empty loadbalance", "          loadbalance: \"\"\n", false),
+	)
+})
+
+// expectSchemaAgreement checks the definition by both the validation and the exported json schema.
+func expectSchemaAgreement(content []byte, valid bool) {
+	_, err := manager.ParseDefinitionSpec(content)
+	Expect(err == nil).To(Equal(valid), "validation: %v", err)
+
+	var document interface{}
+	Expect(yaml.Unmarshal(content, &document)).To(Succeed())
+	violations := schemaViolations(manager.DefinitionJSONSchema(), jsonRoundTrip(document), "")
+	Expect(len(violations) == 0).To(Equal(valid), "json schema: %v", violations)
+}
+
+// jsonRoundTrip converts the decoded yaml to the values decoded from json, which the json schema works on.
+func jsonRoundTrip(value interface{}) interface{} {
+	content, err := json.Marshal(value)
+	Expect(err).To(BeNil())
+
+	var result interface{}
+	Expect(json.Unmarshal(content, &result)).To(Succeed())
+
+	return result
+}
+
+// schemaViolations checks the value by the type, properties, required, items and enum keywords of the json schema.
+func schemaViolations(schema map[string]interface{}, value interface{}, path string) []string {
+	schema = jsonRoundTrip(schema).(map[string]interface{})
+
+	var violations []string
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, expected := range enum {
+			found = found || reflect.DeepEqual(expected, value)
+		}
+		if !found {
+			violations = append(violations, fmt.Sprintf("%s: %v is not one of %v", path, value, enum))
+		}
+	}
+
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return append(violations, fmt.Sprintf("%s: should be an object", path))
+		}
+
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, ok := object[name.(string)]; !ok {
+				violations = append(violations, fmt.Sprintf("%s.%s: is required", path, name))
+			}
+		}
+
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, item := range object {
+			property, ok := properties[name].(map[string]interface{})
+			if !ok {
+				if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+					violations = append(violations, schemaViolations(additional, item, path+"."+name)...)
+				} else if schema["additionalProperties"] == false {
+					violations = append(violations, fmt.Sprintf("%s.%s: unknown field", path, name))
+				}
+				continue
+			}
+			violations = append(violations, schemaViolations(property, item, path+"."+name)...)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return append(violations, fmt.Sprintf("%s: should be an array", path))
+		}
+		for i, item := range items {
+			violations = append(violations, schemaViolations(
+				schema["items"].(map[string]interface{}), item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "integer", "number":
+		if _, ok := value.(float64); !ok {
+			violations = append(violations, fmt.Sprintf("%s: should be a number", path))
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			violations = append(violations, fmt.Sprintf("%s: should be a string", path))
+		}
+	}
+
+	return violations
+}
+
+var _ = Describe("Definition GetArray", func() {
+	It("should return an error instead of panic when the item is not a mapping", func() {
+		definition := manager.NewDefinition(map[string]interface{}{
+			"stages": []interface{}{map[string]interface{}{"name": "prod"}, "test"},
+		})
+
+		_, err := definition.GetArray("stages")
+		Expect(errors.Is(err, manager.ErrDefinitionInvalid)).To(BeTrue())
+	})
+
+	It("should convert the items with interface keys", func() {
+		definition := manager.NewDefinition(map[string]interface{}{
+			"stages": []interface{}{map[interface{}]interface{}{"name": "prod"}},
+		})
+
+		stages, err := definition.GetArray("stages")
+		Expect(err).To(BeNil())
+		Expect(stages[0]["name"]).To(Equal("prod"))
+	})
+})